@@ -0,0 +1,148 @@
+package errgroup
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// AdaptiveConcurrency tune concurrency of a group between `Min` and `maxConcurrency`, see
+// `WithAdaptiveConcurrency`
+type AdaptiveConcurrency struct {
+	// lowest concurrency and the initial one, < 1 mean 1
+	Min int64
+	// attempts taking longer are treated as overload, 0 mean only errors are
+	Latency time.Duration
+	// concurrency is multiplied by it on overload, not in (0, 1) mean 0.9
+	Backoff float64
+}
+
+// raise concurrency by one slot per window of healthy attempts and cut it by `a.Backoff` once an
+// attempt failed or was slow (AIMD), instead of guessing a static `maxConcurrency`, which is
+// the upper bound, errors caused by cancellation are ignored, ignored if `maxConcurrency` <= 0
+func WithAdaptiveConcurrency(a AdaptiveConcurrency) Option {
+	return func(g *Group) {
+		if g.pressure.max <= 0 {
+			return
+		}
+		if a.Min < 1 {
+			a.Min = 1
+		}
+		if a.Min > g.pressure.max {
+			a.Min = g.pressure.max
+		}
+		if a.Backoff <= 0 || a.Backoff >= 1 {
+			a.Backoff = 0.9
+		}
+		g.sema = &adaptiveLimiter{cfg: a, max: float64(g.pressure.max), limit: float64(a.Min)}
+	}
+}
+
+// current concurrency, `maxConcurrency` unless `WithAdaptiveConcurrency`, 0 mean no limit
+func (g *Group) Concurrency() int64 {
+	if a, ok := g.sema.(*adaptiveLimiter); ok {
+		return a.size()
+	}
+	return g.pressure.max
+}
+
+// weighted semaphore of a varying size, waiters are served in FIFO order
+type adaptiveLimiter struct {
+	cfg     AdaptiveConcurrency
+	mu      sync.Mutex
+	max     float64
+	limit   float64
+	cur     int64
+	waiters list.List
+}
+
+type waiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+func (a *adaptiveLimiter) size() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return int64(a.limit)
+}
+
+func (a *adaptiveLimiter) Acquire(ctx context.Context, n int64) error {
+	a.mu.Lock()
+	if a.waiters.Len() == 0 && a.fits(n) {
+		a.cur += n
+		a.mu.Unlock()
+		return nil
+	}
+	w := waiter{n: n, ready: make(chan struct{})}
+	elem := a.waiters.PushBack(w)
+	a.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		a.mu.Lock()
+		select {
+		case <-w.ready:
+			// acquired meanwhile, give it back
+			a.cur -= n
+			a.notify()
+		default:
+			a.waiters.Remove(elem)
+			a.notify()
+		}
+		a.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (a *adaptiveLimiter) Release(n int64) {
+	a.mu.Lock()
+	a.cur -= n
+	a.notify()
+	a.mu.Unlock()
+}
+
+// whether `n` more slots are free, a func heavier than the current size run alone, must hold `a.mu`
+func (a *adaptiveLimiter) fits(n int64) bool {
+	return a.cur+n <= int64(a.limit) || a.cur == 0
+}
+
+// wake waiters in order while slots are free, must hold `a.mu`
+func (a *adaptiveLimiter) notify() {
+	for {
+		front := a.waiters.Front()
+		if front == nil {
+			return
+		}
+		w := front.Value.(waiter)
+		if !a.fits(w.n) {
+			return
+		}
+		a.cur += w.n
+		a.waiters.Remove(front)
+		close(w.ready)
+	}
+}
+
+// adjust the size by an attempt taking `d` and failed with `err`
+func (a *adaptiveLimiter) observe(d time.Duration, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err != nil || (a.cfg.Latency > 0 && d > a.cfg.Latency) {
+		if a.limit *= a.cfg.Backoff; a.limit < float64(a.cfg.Min) {
+			a.limit = float64(a.cfg.Min)
+		}
+		return
+	}
+	if a.limit += 1 / a.limit; a.limit > a.max {
+		a.limit = a.max
+	}
+	a.notify()
+}
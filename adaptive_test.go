@@ -0,0 +1,40 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestAdaptiveConcurrency(t *testing.T) {
+	errOverload := errors.New("adaptive_test: overload")
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 8, true, nil, 0,
+		errgroup.WithAdaptiveConcurrency(errgroup.AdaptiveConcurrency{Min: 1}))
+	if n := g.Concurrency(); n != 1 {
+		t.Errorf("initial g.Concurrency() = %d; want 1", n)
+	}
+
+	for i := 0; i < 100; i++ {
+		g.Go(func() error { return nil })
+	}
+	g.Wait()
+	healthy := g.Concurrency()
+	if healthy <= 1 || healthy > 8 {
+		t.Errorf("g.Concurrency() after healthy funcs = %d; want in (1, 8]", healthy)
+	}
+
+	for i := 0; i < 50; i++ {
+		g.Go(func() error { return errOverload })
+	}
+	g.Wait()
+	if n := g.Concurrency(); n != 1 {
+		t.Errorf("g.Concurrency() after failed funcs = %d; want 1", n)
+	}
+
+	g, _ = errgroup.NewGroupWithContext(context.Background(), 8, true, nil, 0)
+	if n := g.Concurrency(); n != 8 {
+		t.Errorf("g.Concurrency() without adaptive concurrency = %d; want 8", n)
+	}
+}
@@ -0,0 +1,20 @@
+package errgroup
+
+import "context"
+
+// Admitter grant quota to funcs, e.g. a shared quota service or distributed rate limiter, see
+// `WithAdmitter`
+type Admitter interface {
+	// block until `cost` is granted or return error, e.g. once ctx is cancelled or quota is denied
+	Acquire(ctx context.Context, cost int64) error
+	// give back `cost` granted by `Acquire`
+	Release(cost int64)
+}
+
+// ask `a` for the weight of every func before it start, after slots of the group were taken,
+// and give it back once the func returned, a func not admitted fail with the error of `a`
+func WithAdmitter(a Admitter) Option {
+	return func(g *Group) {
+		g.admitter = a
+	}
+}
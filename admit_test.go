@@ -0,0 +1,62 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+type quota struct {
+	mu        sync.Mutex
+	left      int64
+	granted   int64
+	released  int64
+	errDenied error
+}
+
+func (q *quota) Acquire(_ context.Context, cost int64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if cost > q.left {
+		return q.errDenied
+	}
+	q.left -= cost
+	q.granted += cost
+	return nil
+}
+
+func (q *quota) Release(cost int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.released += cost
+}
+
+func TestAdmitter(t *testing.T) {
+	q := &quota{left: 3, errDenied: errors.New("quota exceeded")}
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0, errgroup.WithAdmitter(q))
+	var called int
+	for i := 0; i < 5; i++ {
+		g.Go(func() error {
+			called++
+			return nil
+		})
+	}
+	errs := g.Wait()
+	if called != 3 {
+		t.Errorf("called = %d; want 3", called)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("len(g.Wait()) = %d; want 2", len(errs))
+	}
+	for err := range errs {
+		if !errors.Is(err, q.errDenied) {
+			t.Errorf("g.Wait() = %v; want %v", err, q.errDenied)
+		}
+	}
+	if q.granted != 3 || q.released != 3 {
+		t.Errorf("granted %d, released %d; want 3, 3", q.granted, q.released)
+	}
+}
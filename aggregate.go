@@ -0,0 +1,31 @@
+package errgroup
+
+import "strings"
+
+// Errors is all errors returned by `Wait` as one error, see `WaitErr`
+// `errors.Is` and `errors.As` match any of them
+type Errors []error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// like `Wait` but return collected errors as one `Errors` in the order they occurred, nil if none
+func (g *Group) WaitErr() error {
+	var errs Errors
+	for err := range g.Wait() {
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,37 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestWaitErr(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0)
+	if err := g.WaitErr(); err != nil {
+		t.Errorf("g.WaitErr() = %v; want nil", err)
+	}
+
+	g.GoNamed("read", func() error { return io.EOF })
+	g.GoNamed("open", func() error { return &os.PathError{Op: "open", Path: "missing", Err: os.ErrNotExist} })
+	err := g.WaitErr()
+
+	var errs errgroup.Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("g.WaitErr() = %v; want errgroup.Errors of 2 errors", err)
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("errors.Is(%v, io.EOF) = false; want true", err)
+	}
+	var pathErr *os.PathError
+	if !errors.As(err, &pathErr) || pathErr.Path != "missing" {
+		t.Errorf("errors.As(%v, *os.PathError) found %v; want the failure of %q", err, pathErr, "open")
+	}
+	if want := errs[0].Error() + "; " + errs[1].Error(); err.Error() != want {
+		t.Errorf("g.WaitErr().Error() = %q; want %q", err.Error(), want)
+	}
+}
@@ -0,0 +1,66 @@
+package errgroup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+// ArchetypeConfig is the policy shared by funcs of a recurring kind, see `RegisterArchetype`
+type ArchetypeConfig struct {
+	// deadline of every attempt, 0 mean the group's one, see `WithTaskTimeout`
+	Timeout time.Duration
+	// retry mode replacing the group's one, nil mean use the group's one
+	Retry *RetryOption
+	// concurrency slots a func takes, 0 mean 1
+	Weight int64
+}
+
+var archetypes = struct {
+	sync.RWMutex
+	m map[string]ArchetypeConfig
+}{m: map[string]ArchetypeConfig{}}
+
+// register policy for funcs of kind `name`, e.g. "s3-upload", funcs of that kind are passed by
+// `GoAs`, registering the same name again replace the previous config
+// usually called in `init` so the policy is defined once for a whole codebase
+func RegisterArchetype(name string, cfg ArchetypeConfig) {
+	cfg.Retry = cfg.Retry.clone()
+	if cfg.Weight <= 0 {
+		cfg.Weight = 1
+	}
+	archetypes.Lock()
+	archetypes.m[name] = cfg
+	archetypes.Unlock()
+}
+
+func lookupArchetype(name string) (ArchetypeConfig, bool) {
+	archetypes.RLock()
+	defer archetypes.RUnlock()
+	cfg, ok := archetypes.m[name]
+	return cfg, ok
+}
+
+// running unit func with the policy registered as archetype `name`, its error is wrapped
+// as `task "name": err`, func of unknown archetype is not called and fail at once
+func (g *Group) GoAs(name string, f func(ctx context.Context) error) *Task {
+	t := g.newTask(name, f)
+	cfg, ok := lookupArchetype(name)
+	if !ok {
+		t.fn = func(context.Context) error {
+			return backoff.Permanent(fmt.Errorf("unknown archetype %q", name))
+		}
+		return g.submit(t)
+	}
+	if cfg.Timeout > 0 {
+		t.timeout = cfg.Timeout
+	}
+	t.weight = cfg.Weight
+	if cfg.Retry != nil {
+		t.retryMode = cfg.Retry
+	}
+	return g.submit(t)
+}
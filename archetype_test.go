@@ -0,0 +1,62 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestGoAs(t *testing.T) {
+	errgroup.RegisterArchetype("archetype_test-upload", errgroup.ArchetypeConfig{
+		Timeout: time.Millisecond * 10,
+		Retry: &errgroup.RetryOption{
+			Mode:       errgroup.Constant,
+			Interval:   time.Millisecond,
+			MaxRetries: 2,
+		},
+		Weight: 2,
+	})
+
+	var calls, running, maxRunning int64
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 3)
+	for i := 0; i < 2; i++ {
+		g.GoAs("archetype_test-upload", func(ctx context.Context) error {
+			n := atomic.AddInt64(&running, 1)
+			defer atomic.AddInt64(&running, -1)
+			if n > atomic.LoadInt64(&maxRunning) {
+				atomic.StoreInt64(&maxRunning, n)
+			}
+			atomic.AddInt64(&calls, 1)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}
+	g.GoAs("archetype_test-unknown", func(context.Context) error {
+		t.Errorf("func of unknown archetype was called")
+		return nil
+	})
+
+	errs := g.Wait()
+	if len(errs) != 3 {
+		t.Fatalf("g.Wait() returned %d errors; want 3", len(errs))
+	}
+	var timeouts int
+	for err := range drain(errs) {
+		if errors.Is(err, context.DeadlineExceeded) {
+			timeouts++
+		}
+	}
+	if timeouts != 2 {
+		t.Errorf("%d funcs timed out; want 2", timeouts)
+	}
+	if calls != 6 {
+		t.Errorf("funcs called %d times; want 6 with archetype retries", calls)
+	}
+	if maxRunning != 1 {
+		t.Errorf("%d funcs of weight 2 ran at the same time; want 1", maxRunning)
+	}
+}
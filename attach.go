@@ -0,0 +1,14 @@
+package errgroup
+
+import "sync"
+
+// make `Wait` also wait for `wg`, so legacy code signalling completion through a `sync.WaitGroup`
+// is tracked by the group before it's rewritten into funcs, such code should watch ctx of the
+// group to stop once it's cancelled, `wg` must be added to before `Attach` is called
+func (g *Group) Attach(wg *sync.WaitGroup) {
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		wg.Wait()
+	}()
+}
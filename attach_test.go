@@ -0,0 +1,38 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestAttach(t *testing.T) {
+	errFailed := errors.New("attach_test: failed")
+	g, ctx := errgroup.NewGroupWithContext(context.Background(), 0, false, nil, 0)
+
+	var (
+		wg      sync.WaitGroup
+		stopped int32
+	)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ctx.Done()
+			atomic.AddInt32(&stopped, 1)
+		}()
+	}
+	g.Attach(&wg)
+	g.Go(func() error { return errFailed })
+
+	if errs := g.Wait(); len(errs) != 1 || <-errs != errFailed {
+		t.Errorf("g.Wait() did not report %v", errFailed)
+	}
+	if n := atomic.LoadInt32(&stopped); n != 3 {
+		t.Errorf("g.Wait() returned with %d of 3 legacy goroutines stopped", n)
+	}
+}
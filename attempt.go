@@ -0,0 +1,69 @@
+package errgroup
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// AttemptError is an error returned by one attempt of a func call
+type AttemptError struct {
+	// start from 1 at every run, retries have attempt number greater than 1
+	Attempt int
+	// name and tags of the func, see `TaskError`
+	Name string
+	Tags map[string]string
+	Err  error
+}
+
+func (e *AttemptError) Error() string {
+	if e.Name == "" && len(e.Tags) == 0 {
+		return fmt.Sprintf("attempt %d: %v", e.Attempt, e.Err)
+	}
+	return fmt.Sprintf("attempt %d of %s", e.Attempt, &TaskError{Name: e.Name, Tags: e.Tags, Err: e.Err})
+}
+
+func (e *AttemptError) Unwrap() error {
+	return e.Err
+}
+
+// collect every failed attempt, not only the error after last retry
+type attemptErrs struct {
+	errs []*AttemptError
+	mu   sync.Mutex
+}
+
+// record error of every failed attempt, get them by `AttemptErrors` to find out
+// whether retries of a func fail for the same reason or not
+func WithRecordAttemptErrors() Option {
+	return func(g *Group) {
+		g.attempts = &attemptErrs{}
+	}
+}
+
+// errors of every failed attempt in the order they occurred, nil unless `WithRecordAttemptErrors` is used
+func (g *Group) AttemptErrors() []*AttemptError {
+	if g.attempts == nil {
+		return nil
+	}
+	g.attempts.mu.Lock()
+	defer g.attempts.mu.Unlock()
+	return append([]*AttemptError(nil), g.attempts.errs...)
+}
+
+// wrap attempts `f` of `t` to record error of every attempt if needed, see `countAttempts`
+func (g *Group) recordAttempts(t *task, f func() error) func() error {
+	if g.attempts == nil {
+		return f
+	}
+	return func() error {
+		err := f()
+		if err != nil {
+			attempt := int(atomic.LoadInt64(&t.attempts))
+			g.attempts.mu.Lock()
+			g.attempts.errs = append(g.attempts.errs, &AttemptError{Attempt: attempt, Name: t.name, Tags: t.tags, Err: err})
+			g.attempts.mu.Unlock()
+		}
+		return err
+	}
+}
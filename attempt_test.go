@@ -0,0 +1,82 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestRecordAttemptErrors(t *testing.T) {
+	errTimeout := errors.New("attempt_test: timeout")
+	errRefused := errors.New("attempt_test: connection refused")
+
+	g, _ := errgroup.NewGroupWithContext(
+		context.Background(),
+		1,
+		true,
+		&errgroup.RetryOption{
+			Mode:       errgroup.Constant,
+			Interval:   time.Millisecond,
+			MaxRetries: 2,
+		},
+		1,
+		errgroup.WithRecordAttemptErrors())
+	seq := []error{errTimeout, errRefused, errRefused}
+	i := 0
+	g.Go(func() error {
+		err := seq[i]
+		i++
+		return err
+	})
+	if err := g.Wait(); len(err) != 1 || !errors.Is(<-err, errRefused) {
+		t.Errorf("g.Wait() did not report the error of the last attempt")
+	}
+
+	attempts := g.AttemptErrors()
+	if len(attempts) != len(seq) {
+		t.Fatalf("recorded %d attempt errors; want %d", len(attempts), len(seq))
+	}
+	for i, a := range attempts {
+		if a.Attempt != i+1 || !errors.Is(a, seq[i]) {
+			t.Errorf("attempt error %d = %v; want attempt %d: %v", i, a, i+1, seq[i])
+		}
+	}
+}
+
+func TestRecordAttemptErrorsEvery(t *testing.T) {
+	errDown := errors.New("attempt_test: down")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	g, _ := errgroup.NewGroupWithContext(ctx, 1, true,
+		&errgroup.RetryOption{Mode: errgroup.Constant, Interval: time.Millisecond, MaxRetries: 1}, 0,
+		errgroup.WithRecordAttemptErrors())
+	calls := 0
+	g.GoEvery(time.Millisecond, func(ctx context.Context) error {
+		// 2 runs of 2 attempts each
+		if calls++; calls == 4 {
+			cancel()
+		}
+		return errDown
+	})
+	g.GoTagged(map[string]string{"shard": "1"}, func(context.Context) error { return errDown })
+	g.Wait()
+
+	var every []int
+	for _, a := range g.AttemptErrors() {
+		if len(a.Tags) > 0 {
+			if a.Tags["shard"] != "1" || a.Error() != fmt.Sprintf("attempt %d of task [shard=1]: %v", a.Attempt, errDown) {
+				t.Errorf("attempt error of tagged func = %v; want its tags", a)
+			}
+			continue
+		}
+		every = append(every, a.Attempt)
+	}
+	if len(every) < 4 || !reflect.DeepEqual(every[:4], []int{1, 2, 1, 2}) {
+		t.Errorf("attempts of GoEvery runs = %v; want counted from 1 at every run", every)
+	}
+}
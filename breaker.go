@@ -0,0 +1,112 @@
+package errgroup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+// CircuitBreaker stop calling funcs against a dead dependency, see `WithCircuitBreaker`
+type CircuitBreaker struct {
+	// consecutive failed attempts opening the circuit, < 1 mean 1
+	Threshold int
+	// how long the circuit stays open before a trial attempt is let through
+	CoolOff time.Duration
+	// true mean attempts wait for the circuit to close instead of failing fast
+	Pause bool
+}
+
+// open the circuit once `cb.Threshold` attempts failed in a row, attempts then fail at once with
+// `ErrCircuitOpen` and are not retried (or wait with `cb.Pause`) for `cb.CoolOff`, then a trial
+// attempt is let through, the circuit closes if it succeeded and opens again otherwise
+// errors caused by cancellation are ignored
+func WithCircuitBreaker(cb CircuitBreaker) Option {
+	return func(g *Group) {
+		if cb.Threshold < 1 {
+			cb.Threshold = 1
+		}
+		g.breaker = &breaker{cfg: cb}
+	}
+}
+
+// wrap attempts `f` with the circuit breaker if any
+func (g *Group) guard(f func() error) func() error {
+	if g.breaker == nil {
+		return f
+	}
+	return func() error {
+		if err := g.breaker.allow(g.ctx); err != nil {
+			return err
+		}
+		err := f()
+		g.breaker.record(err)
+		return err
+	}
+}
+
+type breaker struct {
+	cfg      CircuitBreaker
+	mu       sync.Mutex
+	failures int
+	// zero mean closed
+	openUntil time.Time
+	// a trial attempt is running
+	trial bool
+}
+
+// wait or return error unless an attempt is allowed
+func (b *breaker) allow(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		if b.openUntil.IsZero() {
+			b.mu.Unlock()
+			return nil
+		}
+		wait := time.Until(b.openUntil)
+		if wait <= 0 && !b.trial {
+			b.trial = true
+			b.mu.Unlock()
+			return nil
+		}
+		b.mu.Unlock()
+		if !b.cfg.Pause {
+			return backoff.Permanent(ErrCircuitOpen)
+		}
+		if wait <= 0 {
+			// wait for the trial attempt
+			wait = b.cfg.CoolOff / 10
+			if wait <= 0 {
+				wait = time.Millisecond
+			}
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// record the result of an allowed attempt
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// let another trial attempt in even if this one was cancelled
+	b.trial = false
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err == nil {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+	if b.failures++; b.failures >= b.cfg.Threshold {
+		b.openUntil = time.Now().Add(b.cfg.CoolOff)
+	}
+}
@@ -0,0 +1,85 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	errDead := errors.New("breaker_test: dead")
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, &errgroup.RetryOption{
+		Mode:       errgroup.Constant,
+		MaxRetries: 5,
+	}, 0, errgroup.WithCircuitBreaker(errgroup.CircuitBreaker{Threshold: 3, CoolOff: 20 * time.Millisecond}))
+
+	var calls int32
+	dead := func() error {
+		atomic.AddInt32(&calls, 1)
+		return errDead
+	}
+	g.Go(dead)
+	g.Go(dead)
+	errs := g.Wait()
+	if calls != 3 {
+		t.Errorf("dead dependency called %d times; want 3 until the circuit opened", calls)
+	}
+	for err := range errs {
+		if !errors.Is(err, errgroup.ErrCircuitOpen) {
+			t.Errorf("g.Wait() = %v; want %v", err, errgroup.ErrCircuitOpen)
+		}
+	}
+
+	// a trial attempt after cool-off close the circuit
+	time.Sleep(20 * time.Millisecond)
+	calls = 0
+	alive := func() error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}
+	g.Go(alive)
+	g.Go(alive)
+	g.Wait()
+	if calls != 2 {
+		t.Errorf("recovered dependency called %d times after cool-off; want 2", calls)
+	}
+}
+
+func TestCircuitBreakerPause(t *testing.T) {
+	errDead := errors.New("breaker_test: dead")
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0,
+		errgroup.WithCircuitBreaker(errgroup.CircuitBreaker{Threshold: 1, CoolOff: 20 * time.Millisecond, Pause: true}))
+	g.GoNamed("dead", func() error { return errDead })
+	<-g.DoneCh("dead")
+	start := time.Now()
+	g.Go(func() error { return nil })
+	errs := g.Wait()
+	if len(errs) != 1 || !errors.Is(<-errs, errDead) {
+		t.Errorf("g.Wait() did not report only %v", errDead)
+	}
+	if d := time.Since(start); d < 20*time.Millisecond {
+		t.Errorf("func after the circuit opened called after %v; want it paused for 20ms", d)
+	}
+}
+
+func TestCircuitBreakerCancelledTrial(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0,
+		errgroup.WithCircuitBreaker(errgroup.CircuitBreaker{Threshold: 1, CoolOff: 10 * time.Millisecond}))
+	<-g.Go(func() error { return errors.New("breaker_test: dead") }).Done()
+	time.Sleep(20 * time.Millisecond)
+	// the trial attempt is cancelled, the next one must be let in
+	<-g.Go(func() error { return context.Canceled }).Done()
+	called := false
+	<-g.Go(func() error {
+		called = true
+		return nil
+	}).Done()
+	g.Wait()
+	if !called {
+		t.Errorf("breaker stayed open after a cancelled trial attempt")
+	}
+}
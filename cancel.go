@@ -0,0 +1,74 @@
+package errgroup
+
+import "fmt"
+
+// run `fn` once ctx of the group is cancelled, by a failed func, by `Wait` or by the parent ctx
+// callbacks run one by one in the order they were registered and `Wait` not return until all
+// of them finished, `fn` registered while they run, e.g. by one of them, run after them, `fn`
+// registered after that run at once in the caller's goroutine
+func (g *Group) AfterCancel(fn func()) {
+	g.cancelMu.Lock()
+	if !g.cancelled {
+		g.cancelFns = append(g.cancelFns, fn)
+		g.cancelMu.Unlock()
+		return
+	}
+	g.cancelMu.Unlock()
+	<-g.cancelDone
+	fn()
+}
+
+// registered by `context.AfterFunc` to run once ctx of the group is cancelled
+func (g *Group) afterCancel() {
+	defer close(g.cancelDone)
+	g.emit(Event{Kind: GroupCancelled})
+	g.cancelMu.Lock()
+	g.drain(&g.cancelFns)
+	g.cancelled = true
+	g.cancelMu.Unlock()
+}
+
+// run `fn` exactly once after all funcs returned and the group is cancelled, no matter how
+// many goroutines call `Wait`, callbacks run in the order they were registered before the
+// first `Wait` return, `fn` registered while they run, e.g. by one of them, run after them, `fn`
+// registered after that run at once in the caller's goroutine
+func (g *Group) Finally(fn func()) {
+	g.cancelMu.Lock()
+	if !g.finallyRan {
+		g.finallyFns = append(g.finallyFns, fn)
+		g.cancelMu.Unlock()
+		return
+	}
+	g.cancelMu.Unlock()
+	<-g.finallyDone
+	fn()
+}
+
+func (g *Group) runFinally() {
+	defer close(g.finallyDone)
+	g.cancelMu.Lock()
+	g.completed = true
+	g.drain(&g.finallyFns)
+	g.finallyRan = true
+	g.cancelMu.Unlock()
+}
+
+// run and remove callbacks of `fns` until none is left, those registered meanwhile included,
+// must hold `g.cancelMu`, released while a callback runs so it may register more
+func (g *Group) drain(fns *[]func()) {
+	for len(*fns) > 0 {
+		fn := (*fns)[0]
+		*fns = (*fns)[1:]
+		g.cancelMu.Unlock()
+		fn()
+		g.cancelMu.Lock()
+	}
+}
+
+// cause of cancellation by failed `t`, observed by siblings with `context.Cause`
+func cancelCause(t *task, err error) error {
+	if t.name != "" {
+		return fmt.Errorf("errgroup: cancelled by failed task %q: %w", t.name, err)
+	}
+	return fmt.Errorf("errgroup: cancelled by failed func: %w", err)
+}
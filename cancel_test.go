@@ -0,0 +1,146 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestAfterCancel(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	record := func(s string) func() {
+		return func() {
+			mu.Lock()
+			order = append(order, s)
+			mu.Unlock()
+		}
+	}
+
+	g, ctx := errgroup.NewGroupWithContext(context.Background(), 2, false, nil, 1)
+	g.AfterCancel(record("first"))
+	g.AfterCancel(func() {
+		if ctx.Err() == nil {
+			t.Errorf("AfterCancel callback ran before ctx was cancelled")
+		}
+		record("second")()
+	})
+	g.Go(func() error {
+		<-ctx.Done()
+		record("task")()
+		return nil
+	})
+	g.Go(func() error {
+		return errors.New("cancel_test: failed")
+	})
+	g.Wait()
+	g.AfterCancel(record("late"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	got := append([]string(nil), order...)
+	// the task observing ctx.Done may finish before or after the callbacks
+	for i, s := range got {
+		if s == "task" {
+			got = append(got[:i], got[i+1:]...)
+			break
+		}
+	}
+	if want := []string{"first", "second", "late"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AfterCancel callbacks ran in order %v; want %v", got, want)
+	}
+	if len(order) != 4 {
+		t.Errorf("got %d records; want 4", len(order))
+	}
+}
+
+func TestFinally(t *testing.T) {
+	var calls int32
+	g, ctx := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0)
+	g.Finally(func() {
+		if ctx.Err() == nil {
+			t.Errorf("Finally callback ran before the group was cancelled")
+		}
+		atomic.AddInt32(&calls, 1)
+	})
+	for i := 0; i < 4; i++ {
+		g.Go(func() error { return nil })
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.Wait()
+			if atomic.LoadInt32(&calls) != 1 {
+				t.Errorf("Wait returned before Finally callback ran")
+			}
+		}()
+	}
+	wg.Wait()
+
+	late := false
+	g.Finally(func() { late = true })
+	if calls != 1 || !late {
+		t.Errorf("Finally callbacks ran %d times and late one ran %v; want 1 and true", calls, late)
+	}
+}
+
+func TestCancelCause(t *testing.T) {
+	errCharge := errors.New("cancel_test: charge declined")
+	g, ctx := errgroup.NewGroupWithContext(context.Background(), 0, false, nil, 0)
+	cause := make(chan error, 1)
+	g.Go(func() error {
+		<-ctx.Done()
+		cause <- context.Cause(ctx)
+		return ctx.Err()
+	})
+	g.GoNamed("charge", func() error { return errCharge })
+	g.Wait()
+
+	err := <-cause
+	if !errors.Is(err, errCharge) || !strings.Contains(err.Error(), `"charge"`) {
+		t.Errorf("context.Cause(ctx) = %v; want it naming %q and wrapping %v", err, "charge", errCharge)
+	}
+	if ctx.Err() != context.Canceled {
+		t.Errorf("ctx.Err() = %v; want %v", ctx.Err(), context.Canceled)
+	}
+}
+
+func TestCallbacksRegisterCallbacks(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0)
+	var order []string
+	g.AfterCancel(func() {
+		order = append(order, "cancel")
+		g.AfterCancel(func() { order = append(order, "cancel from cancel") })
+	})
+	g.Finally(func() {
+		order = append(order, "finally")
+		g.Finally(func() { order = append(order, "finally from finally") })
+		g.AfterCancel(func() { order = append(order, "cancel from finally") })
+	})
+	done := make(chan struct{})
+	go func() {
+		g.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("g.Wait() blocked by callbacks registered by callbacks")
+	}
+	want := []string{"cancel", "cancel from cancel", "finally", "cancel from finally", "finally from finally"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("callbacks ran in order %q; want %q", order, want)
+	}
+}
@@ -0,0 +1,32 @@
+package errgroup
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// `true` drop errors caused by cancellation of the group by a failed func, i.e.
+// `context.Canceled` returned after it cancelled ctx of the group, so only the root cause
+// failure is reported, once ctx is cancelled otherwise, e.g. by the parent ctx, such errors
+// are reported as the cause of cancellation once, so an unfinished batch never look successful
+// default `true` in fail-fast mode (`waitAll` is `false`) and `false` otherwise
+func WithSuppressCanceled(suppress bool) Option {
+	return func(g *Group) {
+		g.suppressCanceled = suppress
+	}
+}
+
+// whether `err` is derived from cancellation of the group and handled due to
+// `WithSuppressCanceled`, then it's dropped or reported as the cause of cancellation once
+func (g *Group) suppressCanceledErr(err error) bool {
+	if !g.suppressCanceled || g.ctx.Err() == nil || !errors.Is(err, context.Canceled) {
+		return false
+	}
+	if atomic.LoadInt32(&g.failedFast) == 0 {
+		g.causeOnce.Do(func() {
+			g.putErr(context.Cause(g.ctx))
+		})
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestSuppressCanceled(t *testing.T) {
+	errRoot := errors.New("canceled_test: root cause")
+
+	cases := []struct {
+		waitAll bool
+		opts    []errgroup.Option
+		want    int
+	}{
+		{waitAll: false, want: 1},
+		{waitAll: false, opts: []errgroup.Option{errgroup.WithSuppressCanceled(false)}, want: 3},
+		{waitAll: true, want: 3},
+		// not cancelled by the failure, the parent's cause is reported once instead
+		{waitAll: true, opts: []errgroup.Option{errgroup.WithSuppressCanceled(true)}, want: 2},
+	}
+
+	for _, tc := range cases {
+		parent, cancel := context.WithCancel(context.Background())
+		g, ctx := errgroup.NewGroupWithContext(parent, 3, tc.waitAll, nil, 0, tc.opts...)
+		for i := 0; i < 2; i++ {
+			g.Go(func() error {
+				<-ctx.Done()
+				return context.Canceled
+			})
+		}
+		g.Go(func() error { return errRoot })
+		// waitAll mode does not cancel on failure, cancel the parent once the root cause is reported
+		for g.Summary().Failed == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+
+		errs := g.Wait()
+		if len(errs) != tc.want {
+			t.Errorf("waitAll %v: g.Wait() returned %d errors; want %d", tc.waitAll, len(errs), tc.want)
+		}
+		if err := <-errs; err != errRoot {
+			t.Errorf("waitAll %v: first error = %v; want %v", tc.waitAll, err, errRoot)
+		}
+	}
+}
+
+func TestSuppressCanceledParent(t *testing.T) {
+	errStop := errors.New("canceled_test: shutting down")
+	parent, cancel := context.WithCancelCause(context.Background())
+	g, ctx := errgroup.NewGroupWithContext(parent, 2, false, nil, 0)
+	started := make(chan struct{}, 6)
+	for i := 0; i < 6; i++ {
+		g.Go(func() error {
+			started <- struct{}{}
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}
+	<-started
+	<-started
+	cancel(errStop)
+
+	errs := g.Wait()
+	if len(errs) != 1 {
+		t.Fatalf("g.Wait() returned %d errors; want the cause once", len(errs))
+	}
+	if err := <-errs; err != errStop {
+		t.Errorf("g.Wait() = %v; want %v", err, errStop)
+	}
+	if g.Err() != errStop {
+		t.Errorf("g.Err() = %v; want %v", g.Err(), errStop)
+	}
+}
@@ -0,0 +1,29 @@
+package errgroup
+
+import "errors"
+
+// number of collected errors per category given by `classify`, merged errors count as many
+// times as they occurred (see `WithErrorDedup`), errors dropped due to `maxErrs` are not counted
+// it's meant for batches of thousands funcs where listing every error is unreadable
+func (g *Group) ErrorCategories(classify func(err error) string) map[string]int {
+	g.err.mu.Lock()
+	defer g.err.mu.Unlock()
+	categories := make(map[string]int)
+	for _, entry := range g.err.errs {
+		categories[classify(entry.err)] += entry.count
+	}
+	return categories
+}
+
+// classifier for `ErrorCategories` by the first of `targets` an error matches with `errors.Is`,
+// named by the target's message, errors matching none are put in category ""
+func ClassifyIs(targets ...error) func(err error) string {
+	return func(err error) string {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return target.Error()
+			}
+		}
+		return ""
+	}
+}
@@ -0,0 +1,40 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestErrorCategories(t *testing.T) {
+	errTimeout := errors.New("category_test: timeout")
+	errDenied := errors.New("category_test: denied")
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 4, true, nil, 0)
+	for i := 0; i < 10; i++ {
+		i := i
+		g.Go(func() error {
+			switch i % 3 {
+			case 0:
+				return fmt.Errorf("shard %d: %w", i, errTimeout)
+			case 1:
+				return errDenied
+			}
+			return errors.New("category_test: unknown")
+		})
+	}
+	g.Wait()
+
+	got := g.ErrorCategories(errgroup.ClassifyIs(errTimeout, errDenied))
+	want := map[string]int{errTimeout.Error(): 4, errDenied.Error(): 3, "": 3}
+	if len(got) != len(want) {
+		t.Errorf("g.ErrorCategories() = %v; want %v", got, want)
+	}
+	for category, n := range want {
+		if got[category] != n {
+			t.Errorf("category %q has %d errors; want %d", category, got[category], n)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package errgroup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// cleanup funcs registered by a func, see `OnDone`
+type cleanups struct {
+	mu   sync.Mutex
+	fns  []func() error
+	done bool
+}
+
+// run `fn` once the func running with `ctx` returned, after all its attempts and whether it
+// succeeded, failed, panicked or was cancelled, funcs run in reverse order like `defer` and
+// before `Task.Done` is closed, `fn` registered after that run at once in the caller's goroutine
+// errors of `fn` are ignored unless `WithCleanupErrors`, false if `ctx` is not passed by the
+// group, see `GoContext`
+func OnDone(ctx context.Context, fn func() error) bool {
+	t, ok := ctx.Value(taskKey{}).(*task)
+	if !ok {
+		return false
+	}
+	cs := t.cleanups
+	cs.mu.Lock()
+	if !cs.done {
+		cs.fns = append(cs.fns, fn)
+		cs.mu.Unlock()
+		return true
+	}
+	cs.mu.Unlock()
+	t.group.cleanupErr(t, fn())
+	return true
+}
+
+// report errors of funcs registered by `OnDone` like errors of funcs
+func WithCleanupErrors() Option {
+	return func(g *Group) {
+		g.cleanupErrs = true
+	}
+}
+
+// run cleanup funcs of `t` in reverse order
+func (g *Group) cleanup(t *task) {
+	cs := t.cleanups
+	cs.mu.Lock()
+	cs.done = true
+	fns := cs.fns
+	cs.fns = nil
+	cs.mu.Unlock()
+	for i := len(fns) - 1; i >= 0; i-- {
+		g.cleanupErr(t, fns[i]())
+	}
+}
+
+func (g *Group) cleanupErr(t *task, err error) {
+	if err == nil || !g.cleanupErrs {
+		return
+	}
+	g.putErr(t.wrap(fmt.Errorf("cleanup: %w", err)))
+}
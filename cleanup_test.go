@@ -0,0 +1,61 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestOnDone(t *testing.T) {
+	if errgroup.OnDone(context.Background(), func() error { return nil }) {
+		t.Errorf("OnDone(context.Background()) = true; want false")
+	}
+
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, &errgroup.RetryOption{Mode: errgroup.Constant, MaxRetries: 2}, 0)
+	var order []string
+	attempts := 0
+	task := g.GoContext(func(ctx context.Context) error {
+		attempts++
+		errgroup.OnDone(ctx, func() error {
+			order = append(order, "first")
+			return errors.New("cleanup_test: ignored")
+		})
+		errgroup.OnDone(ctx, func() error {
+			order = append(order, "second")
+			return nil
+		})
+		if attempts < 3 {
+			return errors.New("cleanup_test: failed")
+		}
+		panic("cleanup_test: panic")
+	})
+	<-task.Done()
+	// registered once per attempt
+	want := []string{"second", "first", "second", "first", "second", "first"}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("cleanup order = %v; want %v", order, want)
+	}
+	errs := g.Wait()
+	if len(errs) != 1 {
+		t.Errorf("len(g.Wait()) = %d; want 1 as cleanup errors are ignored", len(errs))
+	}
+}
+
+func TestWithCleanupErrors(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0, errgroup.WithCleanupErrors())
+	errCleanup := errors.New("cleanup_test: close failed")
+	g.GoContext(func(ctx context.Context) error {
+		errgroup.OnDone(ctx, func() error { return errCleanup })
+		return nil
+	})
+	errs := g.Wait()
+	if err := <-errs; !errors.Is(err, errCleanup) {
+		t.Errorf("g.Wait() = %v; want %v", err, errCleanup)
+	}
+	if s := g.Summary(); s.Succeeded != 1 {
+		t.Errorf("g.Summary() = %+v; want the func succeeded", s)
+	}
+}
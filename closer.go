@@ -0,0 +1,64 @@
+package errgroup
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+type closersKey struct{}
+
+// resources registered by one attempt of a func
+type closers struct {
+	mu     sync.Mutex
+	list   []io.Closer
+	closed bool
+}
+
+// close `c` on behalf of the func running with `ctx` if it's cancelled mid-flight or panics, so
+// files and connections of half-finished funcs not leak once the group is cancelled, closing
+// them also unblock calls not watching ctx, `c` is closed at once if ctx is already cancelled
+// errors of `Close` are ignored, false if `ctx` is not passed by the group, see `GoContext`
+func RegisterCloser(ctx context.Context, c io.Closer) bool {
+	cs, ok := ctx.Value(closersKey{}).(*closers)
+	if !ok {
+		return false
+	}
+	cs.mu.Lock()
+	if !cs.closed {
+		cs.list = append(cs.list, c)
+		cs.mu.Unlock()
+		return true
+	}
+	cs.mu.Unlock()
+	c.Close()
+	return true
+}
+
+// close registered resources in reverse order
+func (cs *closers) close() {
+	cs.mu.Lock()
+	cs.closed = true
+	list := cs.list
+	cs.list = nil
+	cs.mu.Unlock()
+	for i := len(list) - 1; i >= 0; i-- {
+		list[i].Close()
+	}
+}
+
+// call `fn` with a registry of closers closed once ctx is cancelled or `fn` panics
+func withClosers(ctx context.Context, fn func(ctx context.Context) error) error {
+	cs := &closers{}
+	stop := context.AfterFunc(ctx, cs.close)
+	defer stop()
+	returned := false
+	defer func() {
+		if !returned {
+			cs.close()
+		}
+	}()
+	err := fn(context.WithValue(ctx, closersKey{}, cs))
+	returned = true
+	return err
+}
@@ -0,0 +1,67 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+type conn struct {
+	closed int32
+	done   chan struct{}
+}
+
+func newConn() *conn {
+	return &conn{done: make(chan struct{})}
+}
+
+func (c *conn) Close() error {
+	if atomic.AddInt32(&c.closed, 1) == 1 {
+		close(c.done)
+	}
+	return nil
+}
+
+func TestRegisterCloser(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	panicked, finished := newConn(), newConn()
+	g.GoContext(func(ctx context.Context) error {
+		errgroup.RegisterCloser(ctx, panicked)
+		panic("closer_test: panic")
+	})
+	g.GoContext(func(ctx context.Context) error {
+		errgroup.RegisterCloser(ctx, finished)
+		return nil
+	})
+	g.Wait()
+	if n := atomic.LoadInt32(&panicked.closed); n != 1 {
+		t.Errorf("closer of a panicked func closed %d times; want 1", n)
+	}
+	if n := atomic.LoadInt32(&finished.closed); n != 0 {
+		t.Errorf("closer of a returned func closed %d times; want 0", n)
+	}
+
+	errDown := errors.New("closer_test: down")
+	g, _ = errgroup.NewGroupWithContext(context.Background(), 0, false, nil, 0)
+	blocked, registered := newConn(), make(chan struct{})
+	g.GoContext(func(ctx context.Context) error {
+		errgroup.RegisterCloser(ctx, blocked)
+		close(registered)
+		// a read not watching ctx, unblocked by closing the connection
+		<-blocked.done
+		return nil
+	})
+	<-registered
+	g.Go(func() error { return errDown })
+	g.Wait()
+	if n := atomic.LoadInt32(&blocked.closed); n != 1 {
+		t.Errorf("closer of a cancelled func closed %d times; want 1", n)
+	}
+
+	if errgroup.RegisterCloser(context.Background(), newConn()) {
+		t.Errorf("RegisterCloser(context.Background()) reported registered")
+	}
+}
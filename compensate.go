@@ -0,0 +1,89 @@
+package errgroup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// compensations registered by the running attempt of a func, see `Compensate`
+type undos struct {
+	mu  sync.Mutex
+	fns []func(ctx context.Context) error
+}
+
+// register `undo` to roll back work of the func running with `ctx` if the group fails, it's
+// kept only if the func succeed, undos of failed attempts are dropped, once all funcs returned
+// and an error was reported, undos run in reverse order of registration before `Wait`
+// return, see `WithCompensationConcurrency`, their errors are reported as well
+// false if `ctx` is not passed by the group, see `GoContext`
+func Compensate(ctx context.Context, undo func(ctx context.Context) error) bool {
+	t, ok := ctx.Value(taskKey{}).(*task)
+	if !ok {
+		return false
+	}
+	t.undos.mu.Lock()
+	t.undos.fns = append(t.undos.fns, undo)
+	t.undos.mu.Unlock()
+	return true
+}
+
+// run at most `n` undos registered by `Compensate` at a time, still started in reverse order,
+// < 1 mean 1
+func WithCompensationConcurrency(n int) Option {
+	return func(g *Group) {
+		if n < 1 {
+			n = 1
+		}
+		g.undoLimit = n
+	}
+}
+
+// drop undos of the previous attempt of `t`
+func (u *undos) reset() {
+	u.mu.Lock()
+	u.fns = nil
+	u.mu.Unlock()
+}
+
+// keep undos of `t` once it succeeded
+func (g *Group) keepUndos(t *task) {
+	t.undos.mu.Lock()
+	fns := t.undos.fns
+	t.undos.fns = nil
+	t.undos.mu.Unlock()
+	g.mu.Lock()
+	g.undos = append(g.undos, fns...)
+	g.mu.Unlock()
+}
+
+// run kept undos in reverse order if the group failed
+func (g *Group) compensate() {
+	if g.Err() == nil {
+		return
+	}
+	g.mu.Lock()
+	fns := g.undos
+	g.undos = nil
+	g.mu.Unlock()
+	limit := g.undoLimit
+	if limit < 1 {
+		limit = 1
+	}
+	// funcs of the group are cancelled by now
+	ctx := context.WithoutCancel(g.ctx)
+	sema := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := len(fns) - 1; i >= 0; i-- {
+		sema <- struct{}{}
+		wg.Add(1)
+		go func(undo func(ctx context.Context) error) {
+			defer wg.Done()
+			defer func() { <-sema }()
+			if err := undo(ctx); err != nil {
+				g.putErr(fmt.Errorf("compensate: %w", err))
+			}
+		}(fns[i])
+	}
+	wg.Wait()
+}
@@ -0,0 +1,63 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestCompensate(t *testing.T) {
+	if errgroup.Compensate(context.Background(), func(context.Context) error { return nil }) {
+		t.Errorf("Compensate(context.Background()) = true; want false")
+	}
+
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0)
+	var mu sync.Mutex
+	var undone []string
+	step := func(name string, err error) {
+		task := g.GoContext(func(ctx context.Context) error {
+			errgroup.Compensate(ctx, func(ctx context.Context) error {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				mu.Lock()
+				undone = append(undone, name)
+				mu.Unlock()
+				return nil
+			})
+			return err
+		})
+		<-task.Done()
+	}
+	errFailed := errors.New("compensate_test: step failed")
+	step("reserve", nil)
+	step("charge", nil)
+	step("ship", errFailed)
+	errs := g.Wait()
+	if want := []string{"charge", "reserve"}; !reflect.DeepEqual(undone, want) {
+		t.Errorf("undone = %v; want %v", undone, want)
+	}
+	if len(errs) != 1 || !errors.Is(<-errs, errFailed) {
+		t.Errorf("g.Wait() want only %v", errFailed)
+	}
+}
+
+func TestCompensateSucceeded(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0, errgroup.WithCompensationConcurrency(4))
+	var undone bool
+	g.GoContext(func(ctx context.Context) error {
+		errgroup.Compensate(ctx, func(context.Context) error {
+			undone = true
+			return nil
+		})
+		return nil
+	})
+	g.Wait()
+	if undone {
+		t.Errorf("undo ran though the group succeeded")
+	}
+}
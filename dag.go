@@ -0,0 +1,80 @@
+package errgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// reported as error of deps not passed once `Wait` is called, see `After`
+var errNotPassed = errors.New("errgroup: not passed before Wait")
+
+// declare func named `name` runs only after funcs named `deps` succeeded, so named funcs are
+// scheduled in topological order with maximum parallelism, it fail with `ErrDependencyFailed`
+// without being called once one of them failed, must be called before `name` is passed
+// a func waiting for its deps hold no slot and is kept out of the queue of `WithQueue` and
+// `WithWorkerPool`, deps not passed once `Wait` is called are reported failed, return error if
+// it introduces a cycle
+func (g *Group) After(name string, deps ...string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, dep := range deps {
+		if dep == name || g.dependsOn(dep, name) {
+			return fmt.Errorf("errgroup: %q after %q introduces a cycle", name, dep)
+		}
+	}
+	if g.deps == nil {
+		g.deps = make(map[string][]string)
+	}
+	g.deps[name] = append(g.deps[name], deps...)
+	return nil
+}
+
+// whether `name` depends on `dep` directly or not, must hold `g.mu`
+func (g *Group) dependsOn(name, dep string) bool {
+	for _, d := range g.deps[name] {
+		if d == dep || g.dependsOn(d, dep) {
+			return true
+		}
+	}
+	return false
+}
+
+// deps of `t`, see `After`
+func (g *Group) depsOf(t *task) []string {
+	if t.name == "" {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.deps[t.name]
+}
+
+// wait for deps of `t`, return error if one of them failed or ctx of the group is cancelled
+func (g *Group) waitDeps(t *task) error {
+	deps := g.depsOf(t)
+	for _, dep := range deps {
+		select {
+		case err := <-g.DoneCh(dep):
+			if err != nil {
+				return fmt.Errorf("%w: %q: %w", ErrDependencyFailed, dep, err)
+			}
+		case <-g.slotCtx(t).Done():
+			return fmt.Errorf("%w: %q: %w", ErrDependencyFailed, dep, context.Cause(g.slotCtx(t)))
+		}
+	}
+	return nil
+}
+
+// fail deps never passed once `Wait` is called, so funcs after them not wait forever
+func (g *Group) failUnknownDeps() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for _, deps := range g.deps {
+		for _, dep := range deps {
+			if d := g.doneState(dep); !d.passed {
+				g.fire(d, errNotPassed)
+			}
+		}
+	}
+}
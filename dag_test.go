@@ -0,0 +1,108 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestAfter(t *testing.T) {
+	errSeed := errors.New("dag_test: seed failed")
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	record := func(name string, err error) func() error {
+		return func() error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return err
+		}
+	}
+	// migrate -> {index, seed} -> serve, seed fails
+	for name, deps := range map[string][]string{
+		"index": {"migrate"},
+		"seed":  {"migrate"},
+		"serve": {"index", "seed"},
+	} {
+		if err := g.After(name, deps...); err != nil {
+			t.Fatalf("g.After(%q, %v) = %v; want no error", name, deps, err)
+		}
+	}
+	if err := g.After("migrate", "serve"); err == nil {
+		t.Errorf("g.After() introducing a cycle returned no error")
+	}
+
+	g.GoNamed("serve", record("serve", nil))
+	g.GoNamed("seed", record("seed", errSeed))
+	g.GoNamed("index", record("index", nil))
+	g.GoNamed("migrate", record("migrate", nil))
+	errs := g.Wait()
+
+	if len(order) != 3 || order[0] != "migrate" {
+		t.Errorf("funcs ran in %v; want migrate first, then index and seed", order)
+	}
+	if err := <-g.DoneCh("serve"); !errors.Is(err, errgroup.ErrDependencyFailed) || !errors.Is(err, errSeed) {
+		t.Errorf("func after a failed one returned %v; want %v wrapping %v", err, errgroup.ErrDependencyFailed, errSeed)
+	}
+	if len(errs) != 2 {
+		t.Errorf("g.Wait() returned %d errors; want 2", len(errs))
+	}
+}
+
+func TestAfterQueued(t *testing.T) {
+	for _, opt := range []errgroup.Option{errgroup.WithQueue(10), errgroup.WithWorkerPool()} {
+		g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0, opt)
+		if err := g.After("serve", "migrate"); err != nil {
+			t.Fatal(err)
+		}
+		var order []string
+		// passed before its dep, it must not take the only worker meanwhile
+		g.GoNamed("serve", func() error {
+			order = append(order, "serve")
+			return nil
+		})
+		g.GoNamed("migrate", func() error {
+			order = append(order, "migrate")
+			return nil
+		})
+		done := make(chan chan error)
+		go func() { done <- g.Wait() }()
+		select {
+		case errs := <-done:
+			if len(errs) != 0 || len(order) != 2 || order[0] != "migrate" {
+				t.Errorf("g.Wait() returned %d errors, order %v; want none and migrate first", len(errs), order)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("g.Wait() hang with a func waiting for its dep")
+		}
+	}
+}
+
+func TestAfterNotPassed(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0, errgroup.WithWorkerPool())
+	if err := g.After("serve", "migrate"); err != nil {
+		t.Fatal(err)
+	}
+	called := false
+	g.GoNamed("serve", func() error {
+		called = true
+		return nil
+	})
+	done := make(chan chan error)
+	go func() { done <- g.Wait() }()
+	select {
+	case errs := <-done:
+		if err := <-errs; !errors.Is(err, errgroup.ErrDependencyFailed) || called {
+			t.Errorf("g.Wait() = %v, called %v; want ErrDependencyFailed and not called", err, called)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("g.Wait() hang with a dep never passed")
+	}
+}
@@ -0,0 +1,35 @@
+package errgroup
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// cancel all `groups` together once `d` elapsed, so a pipeline of several groups enforce one
+// end-to-end deadline, groups not completed by then report `ErrGroupTimeout` and their ctx
+// has it as cause, call the returned func to stop the deadline, e.g. once the pipeline finished
+func WithSharedDeadline(d time.Duration, groups ...*Group) (stop func()) {
+	timer := time.AfterFunc(d, func() {
+		cause := fmt.Errorf("%w: %w", ErrGroupTimeout, context.DeadlineExceeded)
+		for _, g := range groups {
+			g.timeout(cause)
+		}
+	})
+	return func() { timer.Stop() }
+}
+
+// cancel the group with `cause` unless it completed
+func (g *Group) timeout(cause error) {
+	g.cancelMu.Lock()
+	completed := g.completed
+	g.cancelMu.Unlock()
+	if completed || g.ctx.Err() != nil {
+		return
+	}
+	// reported as the cause of cancellation, so `WithSuppressCanceled` not report it again
+	g.causeOnce.Do(func() {
+		g.putErr(cause)
+	})
+	g.cancel(cause)
+}
@@ -0,0 +1,51 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestSharedDeadline(t *testing.T) {
+	extract, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	load, loadCtx := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	stop := errgroup.WithSharedDeadline(20*time.Millisecond, extract, load)
+	defer stop()
+
+	extract.Go(func() error { return nil })
+	if errs := extract.Wait(); len(errs) > 0 {
+		t.Errorf("extract.Wait() = %v; want no error", <-errs)
+	}
+
+	load.Go(func() error {
+		<-loadCtx.Done()
+		return nil
+	})
+	errs := load.Wait()
+	if len(errs) != 1 || !errors.Is(<-errs, errgroup.ErrGroupTimeout) {
+		t.Errorf("load.Wait() did not report %v", errgroup.ErrGroupTimeout)
+	}
+	if cause := context.Cause(loadCtx); !errors.Is(cause, errgroup.ErrGroupTimeout) {
+		t.Errorf("context.Cause(ctx) = %v; want %v", cause, errgroup.ErrGroupTimeout)
+	}
+}
+
+func TestSharedDeadlineFailFast(t *testing.T) {
+	g, ctx := errgroup.NewGroupWithContext(context.Background(), 0, false, nil, 0)
+	stop := errgroup.WithSharedDeadline(20*time.Millisecond, g)
+	defer stop()
+	g.GoContext(func(context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	var errs []error
+	for err := range g.Wait() {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], errgroup.ErrGroupTimeout) {
+		t.Errorf("g.Wait() = %v; want ErrGroupTimeout once", errs)
+	}
+}
@@ -0,0 +1,79 @@
+package errgroup
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+)
+
+// panic with a descriptive message on misuse which otherwise hang or silently misbehave:
+// `Wait` called from a func of the group, funcs passed after `Wait` returned and weights
+// larger than `maxConcurrency`, it costs a stack read per func so keep it for tests and debug
+func WithDebug() Option {
+	return func(g *Group) {
+		g.debug = true
+	}
+}
+
+// check `t` is passed rightly, must be called before it's counted
+func (g *Group) checkSubmit(t *task) {
+	if !g.debug {
+		return
+	}
+	g.cancelMu.Lock()
+	completed := g.completed
+	g.cancelMu.Unlock()
+	if completed {
+		panic(fmt.Sprintf("errgroup: func %q passed after Wait returned, it's never waited for", t.name))
+	}
+	if g.pressure.max > 0 && t.weight > g.pressure.max {
+		panic(fmt.Sprintf("errgroup: func %q weighs %d, more than maxConcurrency %d", t.name, t.weight, g.pressure.max))
+	}
+}
+
+// check `Wait` is not called from a func of the group, which would wait for itself forever
+func (g *Group) checkWait() {
+	if !g.debug {
+		return
+	}
+	g.mu.Lock()
+	_, inside := g.taskGoroutines[goroutineID()]
+	g.mu.Unlock()
+	if inside {
+		panic("errgroup: Wait called from a func of the group would wait for itself forever")
+	}
+}
+
+// track goroutine running a func of the group, return func to untrack it
+func (g *Group) enter() func() {
+	if !g.debug {
+		return func() {}
+	}
+	id := goroutineID()
+	g.mu.Lock()
+	if g.taskGoroutines == nil {
+		g.taskGoroutines = make(map[uint64]int)
+	}
+	g.taskGoroutines[id]++
+	g.mu.Unlock()
+	return func() {
+		g.mu.Lock()
+		if g.taskGoroutines[id]--; g.taskGoroutines[id] == 0 {
+			delete(g.taskGoroutines, id)
+		}
+		g.mu.Unlock()
+	}
+}
+
+// id of the calling goroutine parsed from its stack, "goroutine 18 [running]:"
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i > 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
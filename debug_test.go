@@ -0,0 +1,50 @@
+package errgroup_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+// call `f` and return the value it panicked with
+func recovered(f func()) (v interface{}) {
+	defer func() { v = recover() }()
+	f()
+	return nil
+}
+
+func TestDebugWaitInside(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0, errgroup.WithDebug())
+	var v interface{}
+	g.Go(func() error {
+		v = recovered(func() { g.Wait() })
+		return nil
+	})
+	g.Wait()
+	if msg, _ := v.(string); !strings.Contains(msg, "Wait called from a func of the group") {
+		t.Errorf("g.Wait() in a func panicked with %v; want misuse reported", v)
+	}
+}
+
+func TestDebugSubmit(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0, errgroup.WithDebug())
+	v := recovered(func() { g.GoWeighted(3, func() error { return nil }) })
+	if msg, _ := v.(string); !strings.Contains(msg, "weighs 3, more than maxConcurrency 2") {
+		t.Errorf("g.GoWeighted(3) panicked with %v; want misuse reported", v)
+	}
+	g.Go(func() error { return nil })
+	g.Wait()
+	v = recovered(func() { g.GoNamed("late", func() error { return nil }) })
+	if msg, _ := v.(string); !strings.Contains(msg, `func "late" passed after Wait returned`) {
+		t.Errorf("g.GoNamed() after Wait panicked with %v; want misuse reported", v)
+	}
+
+	g, _ = errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0)
+	g.GoWeighted(3, func() error { return nil })
+	g.Wait()
+	// misuse is not reported without debug
+	g.Go(func() error { return nil })
+	g.Wait()
+}
@@ -0,0 +1,41 @@
+package errgroup
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CountedError is an error occurred more than once, see `WithErrorDedup`
+type CountedError struct {
+	Err   error
+	Count int
+}
+
+func (e *CountedError) Error() string {
+	return fmt.Sprintf("%v (occurred %d times)", e.Err, e.Count)
+}
+
+func (e *CountedError) Unwrap() error {
+	return e.Err
+}
+
+// merge identical errors (same message, or `errors.Is` equal) into one, errors occurred more
+// than once are reported as `*CountedError` with the number of occurrences
+// merged errors not count against `maxErrs`
+func WithErrorDedup() Option {
+	return func(g *Group) {
+		g.err.dedup = true
+	}
+}
+
+func sameError(a, b error) bool {
+	return a.Error() == b.Error() || errors.Is(a, b) || errors.Is(b, a)
+}
+
+// error to report for the entry
+func (e *errEntry) error() error {
+	if e.count > 1 {
+		return &CountedError{Err: e.err, Count: e.count}
+	}
+	return e.err
+}
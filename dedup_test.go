@@ -0,0 +1,56 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestErrorDedup(t *testing.T) {
+	errRefused := errors.New("dedup_test: connection refused")
+	errNotFound := errors.New("dedup_test: not found")
+
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 4, true, nil, 2, errgroup.WithErrorDedup())
+	for i := 0; i < 200; i++ {
+		g.Go(func() error { return fmt.Errorf("dial: %w", errRefused) })
+	}
+	g.Go(func() error { return errNotFound })
+	g.Go(func() error { return errRefused })
+
+	var refused, notFound int
+	for err := range drain(g.Wait()) {
+		count := 1
+		var ce *errgroup.CountedError
+		if errors.As(err, &ce) {
+			count = ce.Count
+		}
+		switch {
+		case errors.Is(err, errRefused):
+			refused += count
+		case errors.Is(err, errNotFound):
+			notFound += count
+		default:
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+	// wrapped and bare errRefused are merged as errors.Is equal
+	if refused != 201 {
+		t.Errorf("errRefused reported %d times; want 201", refused)
+	}
+	if notFound != 1 {
+		t.Errorf("errNotFound reported %d times; want 1", notFound)
+	}
+}
+
+// receive all buffered errors without blocking
+func drain(errs chan error) chan error {
+	out := make(chan error, len(errs))
+	for len(errs) > 0 {
+		out <- <-errs
+	}
+	close(out)
+	return out
+}
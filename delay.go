@@ -0,0 +1,32 @@
+package errgroup
+
+import (
+	"context"
+	"time"
+)
+
+// running unit func after `delay`, e.g. to stagger warm-ups, it take no slot meanwhile nor
+// worker of `WithQueue`, and is never called if ctx of the group is cancelled before, `Wait`
+// wait for it as well
+func (g *Group) GoAfter(delay time.Duration, f func(ctx context.Context) error) *Task {
+	t := g.newTask("", f)
+	t.delay = delay
+	return g.submit(t)
+}
+
+// wait until delay of `t` passed since it was passed, return error if ctx is cancelled meanwhile
+func (g *Group) sleep(t *task) error {
+	delay := time.Until(t.submitted.Add(t.delay))
+	if t.delay <= 0 || delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	ctx := g.slotCtx(t)
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
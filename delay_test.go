@@ -0,0 +1,83 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestGoAfter(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0)
+	start := time.Now()
+	var startedAfter time.Duration
+	g.GoAfter(30*time.Millisecond, func(ctx context.Context) error {
+		startedAfter = time.Since(start)
+		return nil
+	})
+	// the delayed func take no slot meanwhile
+	var called time.Duration
+	g.Go(func() error {
+		called = time.Since(start)
+		return nil
+	})
+	g.Wait()
+	if startedAfter < 30*time.Millisecond {
+		t.Errorf("delayed func started after %v; want >= 30ms", startedAfter)
+	}
+	if called >= 30*time.Millisecond {
+		t.Errorf("func started after %v; want before the delayed one", called)
+	}
+}
+
+func TestGoAfterQueued(t *testing.T) {
+	for _, opt := range []errgroup.Option{errgroup.WithQueue(10), errgroup.WithWorkerPool()} {
+		g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0, opt)
+		start := time.Now()
+		var startedAfter time.Duration
+		g.GoAfter(30*time.Millisecond, func(ctx context.Context) error {
+			startedAfter = time.Since(start)
+			return nil
+		})
+		// the delayed func take no worker meanwhile
+		var called time.Duration
+		g.Go(func() error {
+			called = time.Since(start)
+			return nil
+		})
+		g.Wait()
+		if startedAfter < 30*time.Millisecond {
+			t.Errorf("delayed func started after %v; want >= 30ms", startedAfter)
+		}
+		if called >= 30*time.Millisecond {
+			t.Errorf("func started after %v; want before the delayed one", called)
+		}
+	}
+}
+
+func TestGoAfterCancelled(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, false, nil, 0)
+	var called bool
+	task := g.GoAfter(time.Minute, func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	errFailed := errors.New("delay_test: failed")
+	g.Go(func() error { return errFailed })
+	start := time.Now()
+	errs := g.Wait()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("g.Wait() took %v; want the delayed func cancelled", elapsed)
+	}
+	if called {
+		t.Errorf("delayed func called after the group was cancelled")
+	}
+	if len(errs) != 1 || !errors.Is(<-errs, errFailed) {
+		t.Errorf("g.Wait() want only %v", errFailed)
+	}
+	if err := task.Err(); !errors.Is(err, context.Canceled) {
+		t.Errorf("task.Err() = %v; want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,144 @@
+package errgroup
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/sync/semaphore"
+)
+
+func (m RetryMode) String() string {
+	switch m {
+	case Zero:
+		return "zero"
+	case Constant:
+		return "constant"
+	case Exponential:
+		return "exponential"
+	default:
+		return fmt.Sprintf("RetryMode(%d)", uint8(m))
+	}
+}
+
+// describe effective retry settings, e.g. `retry(constant 30ms, max 3)`, "no retry" if nil
+func (r *RetryOption) String() string {
+	if r == nil {
+		return "no retry"
+	}
+	var b strings.Builder
+	b.WriteString("retry(")
+	switch {
+	case r.Selector != nil:
+		b.WriteString("selector")
+	case r.Mode == Constant:
+		fmt.Fprintf(&b, "constant %v", r.Interval)
+	default:
+		b.WriteString(r.Mode.String())
+	}
+	fmt.Fprintf(&b, ", max %d", r.MaxRetries)
+	if r.RetryIf != nil {
+		b.WriteString(", conditional")
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// describe effective settings of the group, e.g.
+// `errgroup(concurrency 4, fail-fast, errors 3, retry(constant 30ms, max 3), dedup)`
+// so `%v` in logs and panics shows the configuration in force
+func (g *Group) String() string {
+	var b strings.Builder
+	b.WriteString("errgroup(")
+	if g.pressure.max > 0 {
+		fmt.Fprintf(&b, "concurrency %d", g.pressure.max)
+	} else if g.sema == nil {
+		b.WriteString("concurrency unlimited")
+	} else {
+		b.WriteString("concurrency by limiter")
+	}
+	if g.waitAll {
+		b.WriteString(", wait-all")
+	} else {
+		b.WriteString(", fail-fast")
+	}
+	if g.err.max > 0 {
+		fmt.Fprintf(&b, ", errors %d", g.err.max)
+	} else {
+		b.WriteString(", errors unlimited")
+	}
+	fmt.Fprintf(&b, ", %v", g.retryMode)
+	if g.stallAfter > 0 {
+		fmt.Fprintf(&b, ", stall after %v", g.stallAfter)
+	}
+	if g.taskTimeout > 0 {
+		fmt.Fprintf(&b, ", task timeout %v", g.taskTimeout)
+	}
+	if g.goroutines != nil {
+		fmt.Fprintf(&b, ", goroutines %d", cap(g.goroutines))
+	}
+	if g.queue != nil && g.queue.pool {
+		b.WriteString(", worker pool")
+	}
+	if g.queue != nil {
+		if g.queue.size > 0 {
+			fmt.Fprintf(&b, ", queue %d", g.queue.size)
+		} else {
+			b.WriteString(", queue unlimited")
+		}
+	}
+	if g.keys != nil {
+		fmt.Fprintf(&b, ", key limit %d", g.keys.limit)
+	}
+	flags := []struct {
+		on   bool
+		name string
+	}{
+		{isAdaptive(g.sema), "adaptive"},
+		{isCustom(g.sema), "limiter"},
+		{g.rate != nil, "rate-limit"},
+		{g.breaker != nil, "circuit-breaker"},
+		{g.shedder != nil, "load-shedding"},
+		{g.memory != nil, "memory-admission"},
+		{g.admitter != nil, "admitter"},
+		{g.preflight != nil, "preflight"},
+		{g.postflight != nil, "postflight"},
+		{g.desync, "desync"},
+		{g.err.dedup, "dedup"},
+		{g.err.sampling != nil, "sampling"},
+		{g.attempts != nil, "attempt-errors"},
+		{g.stacks, "stacks"},
+		{g.cleanupErrs, "cleanup-errors"},
+		{g.repanic, "repanic"},
+		{g.suppressCanceled, "suppress-canceled"},
+		{g.audit != nil, "audit"},
+		{g.onEvent != nil, "event-handler"},
+		{g.hooks != nil, "hooks"},
+		{g.durations != nil, "durations"},
+		{g.timeline != nil, "timeline"},
+		{g.debug, "debug"},
+		{g.queue != nil && g.queue.order == LIFO, "lifo"},
+		{g.queue != nil && g.queue.fair, "fair-keys"},
+	}
+	for _, f := range flags {
+		if f.on {
+			b.WriteString(", ")
+			b.WriteString(f.name)
+		}
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+func isAdaptive(l Limiter) bool {
+	_, ok := l.(*adaptiveLimiter)
+	return ok
+}
+
+// `l` was passed by `WithLimiter`
+func isCustom(l Limiter) bool {
+	switch l.(type) {
+	case nil, *adaptiveLimiter, *semaphore.Weighted:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package errgroup_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestRetryOptionString(t *testing.T) {
+	var none *errgroup.RetryOption
+	cases := []struct {
+		r    *errgroup.RetryOption
+		want string
+	}{
+		{r: none, want: "no retry"},
+		{r: errgroup.RetryNetworkDefault(), want: "retry(constant 30ms, max 3)"},
+		{r: errgroup.RetryConservative(), want: "retry(exponential, max 2)"},
+		{r: &errgroup.RetryOption{MaxRetries: 1, RetryIf: errgroup.IsTransient}, want: "retry(zero, max 1, conditional)"},
+	}
+	for _, tc := range cases {
+		if got := fmt.Sprint(tc.r); got != tc.want {
+			t.Errorf("fmt.Sprint(%#v) = %q; want %q", tc.r, got, tc.want)
+		}
+	}
+}
+
+func TestGroupString(t *testing.T) {
+	cases := []struct {
+		g    func() *errgroup.Group
+		want string
+	}{
+		{
+			g: func() *errgroup.Group {
+				g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+				return g
+			},
+			want: "errgroup(concurrency unlimited, wait-all, errors unlimited, no retry)",
+		},
+		{
+			g: func() *errgroup.Group {
+				g, _ := errgroup.NewGroupWithContext(context.Background(), 4, false, &errgroup.RetryOption{
+					Mode:       errgroup.Constant,
+					Interval:   time.Millisecond * 30,
+					MaxRetries: 3,
+				}, 3, errgroup.WithErrorDedup(), errgroup.WithMaxGoroutines(8))
+				return g
+			},
+			want: "errgroup(concurrency 4, fail-fast, errors 3, retry(constant 30ms, max 3), goroutines 8, dedup, suppress-canceled)",
+		},
+	}
+	for _, tc := range cases {
+		if got := fmt.Sprint(tc.g()); got != tc.want {
+			t.Errorf("fmt.Sprint(g) = %q; want %q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package errgroup
+
+import (
+	"errors"
+	"fmt"
+)
+
+// completion of funcs named `name`, see `DoneCh`
+type doneState struct {
+	// a func of the name was passed
+	passed  bool
+	fired   bool
+	err     error
+	waiters []chan error
+}
+
+// channel receiving the error (nil on success) of the func named `name` once it returned or
+// was skipped, so other components can await e.g. "schema-migrated" while the group keeps
+// running, it can be called before the func is passed, every call return a new channel
+// names should be unique, the first returned func of a name is reported, the channel is
+// closed without a value if the group completed without such func, see `Wait`
+func (g *Group) DoneCh(name string) <-chan error {
+	ch := make(chan error, 1)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	d := g.doneState(name)
+	switch {
+	case d.fired:
+		ch <- d.err
+		close(ch)
+	case g.doneClosed:
+		close(ch)
+	default:
+		d.waiters = append(d.waiters, ch)
+	}
+	return ch
+}
+
+// must hold `g.mu`
+func (g *Group) doneState(name string) *doneState {
+	if g.doneStates == nil {
+		g.doneStates = make(map[string]*doneState)
+	}
+	d, ok := g.doneStates[name]
+	if !ok {
+		d = &doneState{}
+		g.doneStates[name] = d
+	}
+	return d
+}
+
+// report `t` returned with `err`
+func (g *Group) done(t *task, err error) {
+	if err != nil && t.cancelled() && !errors.Is(err, ErrTaskCancelled) {
+		err = fmt.Errorf("%w: %w", ErrTaskCancelled, err)
+	}
+	t.err = err
+	close(t.finished)
+	if t.name == "" {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.fire(g.doneState(t.name), err)
+}
+
+// report the first returned func of a name, must hold `g.mu`
+func (g *Group) fire(d *doneState, err error) {
+	if d.fired {
+		return
+	}
+	d.fired, d.err = true, err
+	for _, ch := range d.waiters {
+		ch <- err
+		close(ch)
+	}
+	d.waiters = nil
+}
+
+// close channels of funcs never passed once the group completed
+func (g *Group) closeDone() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.doneClosed = true
+	for _, d := range g.doneStates {
+		for _, ch := range d.waiters {
+			close(ch)
+		}
+		d.waiters = nil
+	}
+}
@@ -0,0 +1,43 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestDoneCh(t *testing.T) {
+	errSeed := errors.New("done_test: seed failed")
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+
+	migrated := g.DoneCh("schema-migrated")
+	release := make(chan struct{})
+	g.GoNamed("schema-migrated", func() error {
+		<-release
+		return nil
+	})
+	g.GoNamed("seed", func() error { return errSeed })
+	g.Go(func() error {
+		// wait for the migration while the group keeps running other funcs
+		if err := <-migrated; err != nil {
+			t.Errorf("<-g.DoneCh(%q) = %v; want nil", "schema-migrated", err)
+		}
+		return nil
+	})
+
+	if err := <-g.DoneCh("seed"); !errors.Is(err, errSeed) {
+		t.Errorf("<-g.DoneCh(%q) = %v; want %v", "seed", err, errSeed)
+	}
+	missing := g.DoneCh("missing")
+	close(release)
+	g.Wait()
+
+	if err, ok := <-g.DoneCh("seed"); !ok || !errors.Is(err, errSeed) {
+		t.Errorf("g.DoneCh(%q) after g.Wait() = %v, %v; want %v", "seed", err, ok, errSeed)
+	}
+	if _, ok := <-missing; ok {
+		t.Errorf("g.DoneCh(%q) of a func never passed received a value", "missing")
+	}
+}
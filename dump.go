@@ -0,0 +1,65 @@
+package errgroup
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// write settings of the group and funcs running now, longest running first, with when they were
+// passed, how long they waited, their attempt and how long they hold their slots, to find out
+// why `Wait` appears hung
+func (g *Group) Dump(w io.Writer) error {
+	type running struct {
+		name                string
+		submitted, acquired time.Time
+		attempt             int64
+	}
+	g.mu.Lock()
+	tasks := make([]running, 0, len(g.runningTasks))
+	for t := range g.runningTasks {
+		tasks = append(tasks, running{t.name, t.submitted, t.acquired, atomic.LoadInt64(&t.attempts)})
+	}
+	g.mu.Unlock()
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].acquired.Before(tasks[j].acquired)
+	})
+	s := g.Stats()
+	if _, err := fmt.Fprintf(w, "%v\nrunning %d, queued %d, succeeded %d, failed %d, elapsed %v\n",
+		g, s.Running, s.Queued, s.Succeeded, s.Failed, s.Elapsed.Round(time.Millisecond)); err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, t := range tasks {
+		name := t.name
+		if name == "" {
+			name = "<unnamed>"
+		}
+		if _, err := fmt.Fprintf(w, "  %s: passed %s, waited %v, attempt %d, holding slots %v\n",
+			name, t.submitted.Format("15:04:05.000"), t.acquired.Sub(t.submitted).Round(time.Millisecond),
+			t.attempt, now.Sub(t.acquired).Round(time.Millisecond)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// track `t` as running, see `Dump`
+func (g *Group) startRunning(t *task) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.runningTasks == nil {
+		g.runningTasks = make(map[*task]struct{})
+	}
+	g.runningTasks[t] = struct{}{}
+	g.running++
+}
+
+func (g *Group) stopRunning(t *task) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.runningTasks, t)
+	g.running--
+}
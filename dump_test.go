@@ -0,0 +1,45 @@
+package errgroup_test
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestDump(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0)
+	started, release := make(chan struct{}, 2), make(chan struct{})
+	g.GoNamed("hung", func() error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+	<-started
+	time.Sleep(10 * time.Millisecond)
+	g.Go(func() error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+	<-started
+	g.Go(func() error { return nil })
+
+	var buf bytes.Buffer
+	if err := g.Dump(&buf); err != nil {
+		t.Fatalf("g.Dump() = %v", err)
+	}
+	close(release)
+	g.Wait()
+	want := regexp.MustCompile(`^errgroup\(concurrency 2, .*\)
+running 2, queued 1, succeeded 0, failed 0, elapsed \S+
+  hung: passed \d\d:\d\d:\d\d\.\d{3}, waited \S+, attempt 1, holding slots \S+
+  <unnamed>: passed \d\d:\d\d:\d\d\.\d{3}, waited \S+, attempt 1, holding slots \S+
+$`)
+	if !want.MatchString(buf.String()) {
+		t.Errorf("g.Dump() wrote\n%s\nwant match %s", buf.String(), want)
+	}
+}
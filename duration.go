@@ -0,0 +1,71 @@
+package errgroup
+
+import (
+	"sort"
+	"time"
+)
+
+// TaskDuration is how long a func ran, retries included
+type TaskDuration struct {
+	// empty for unnamed funcs
+	Name     string
+	Duration time.Duration
+}
+
+// DurationReport summarize how long funcs ran, see `Durations`
+type DurationReport struct {
+	// number of funcs ran
+	Count int
+	// percentiles of durations, nearest rank
+	P50, P90, P99, Max time.Duration
+	// slowest funcs, slowest first
+	Slowest []TaskDuration
+}
+
+// record how long every func ran, see `Durations`
+func WithDurations() Option {
+	return func(g *Group) {
+		g.durations = []TaskDuration{}
+	}
+}
+
+// report durations of funcs ran so far with the `n` slowest ones, to find stragglers dominating
+// the wall-clock time of a batch, usually called after `Wait`, empty unless `WithDurations`
+func (g *Group) Durations(n int) DurationReport {
+	g.mu.Lock()
+	durations := append([]TaskDuration(nil), g.durations...)
+	g.mu.Unlock()
+	if len(durations) == 0 {
+		return DurationReport{}
+	}
+	sort.SliceStable(durations, func(i, j int) bool {
+		return durations[i].Duration > durations[j].Duration
+	})
+	// index of percentile `p` in descending order
+	rank := func(p int) time.Duration {
+		i := (len(durations)*p + 99) / 100
+		return durations[len(durations)-i].Duration
+	}
+	if n > len(durations) {
+		n = len(durations)
+	}
+	if n < 0 {
+		n = 0
+	}
+	return DurationReport{
+		Count:   len(durations),
+		P50:     rank(50),
+		P90:     rank(90),
+		P99:     rank(99),
+		Max:     durations[0].Duration,
+		Slowest: durations[:n],
+	}
+}
+
+func (g *Group) recordDuration(t *task, d time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.durations != nil {
+		g.durations = append(g.durations, TaskDuration{Name: t.name, Duration: d})
+	}
+}
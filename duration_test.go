@@ -0,0 +1,42 @@
+package errgroup_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestDurations(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	g.Go(func() error { return nil })
+	g.Wait()
+	if r := g.Durations(3); r.Count != 0 {
+		t.Errorf("g.Durations() = %+v without WithDurations; want empty", r)
+	}
+
+	g, _ = errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0, errgroup.WithDurations())
+	for i := 1; i <= 10; i++ {
+		d := time.Duration(i) * 10 * time.Millisecond
+		g.GoNamed(fmt.Sprint(i), func() error {
+			time.Sleep(d)
+			return nil
+		})
+	}
+	g.Wait()
+	r := g.Durations(2)
+	if r.Count != 10 {
+		t.Errorf("Count = %d; want 10", r.Count)
+	}
+	if len(r.Slowest) != 2 || r.Slowest[0].Name != "10" || r.Slowest[1].Name != "9" {
+		t.Errorf("Slowest = %v; want funcs 10 and 9", r.Slowest)
+	}
+	if r.Max != r.Slowest[0].Duration || r.P99 != r.Max || r.P90 != r.Slowest[1].Duration {
+		t.Errorf("report = %+v; want p99 = max and p90 the second slowest", r)
+	}
+	if r.P50 < 50*time.Millisecond || r.P50 >= 60*time.Millisecond {
+		t.Errorf("P50 = %v; want about 50ms", r.P50)
+	}
+}
@@ -0,0 +1,17 @@
+package errgroup
+
+import "context"
+
+// running unit func for every item of `items` in `g`, `fn` get index and value of the item
+// so results can be stored by index, mix freely with other funcs of the group and one `Wait`,
+// return handles of the funcs in the order of `items`
+func Each[T any](g *Group, items []T, fn func(ctx context.Context, i int, v T) error) []*Task {
+	tasks := make([]*Task, len(items))
+	for i, v := range items {
+		i, v := i, v
+		tasks[i] = g.submit(g.newTask("", func(ctx context.Context) error {
+			return fn(ctx, i, v)
+		}))
+	}
+	return tasks
+}
@@ -0,0 +1,52 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestEach(t *testing.T) {
+	errOdd := errors.New("each_test: odd")
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0)
+
+	items := []int{1, 2, 3, 4}
+	squares := make([]int, len(items))
+	tasks := errgroup.Each(g, items, func(_ context.Context, i int, v int) error {
+		squares[i] = v * v
+		if v%2 == 1 {
+			return errOdd
+		}
+		return nil
+	})
+	extra := false
+	g.Go(func() error {
+		extra = true
+		return nil
+	})
+
+	errs := g.Wait()
+	if len(errs) != 2 {
+		t.Errorf("g.Wait() returned %d errors; want 2", len(errs))
+	}
+	for err := range errs {
+		if err != errOdd {
+			t.Errorf("g.Wait() returned %v; want %v", err, errOdd)
+		}
+	}
+	for i, v := range items {
+		if squares[i] != v*v {
+			t.Errorf("squares[%d] = %d; want %d", i, squares[i], v*v)
+		}
+	}
+	for i, task := range tasks {
+		if err := task.Err(); (items[i]%2 == 1) != errors.Is(err, errOdd) {
+			t.Errorf("tasks[%d].Err() = %v for item %d", i, err, items[i])
+		}
+	}
+	if !extra {
+		t.Errorf("func passed by g.Go was not called")
+	}
+}
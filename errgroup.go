@@ -2,149 +2,717 @@ package errgroup
 
 import (
 	"context"
+	"fmt"
+	"runtime/debug"
+	"runtime/pprof"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cenkalti/backoff"
 	"golang.org/x/sync/semaphore"
 )
 
-type RetryMode uint8
+// collect errors during group life time, keep at most `max` errors, all errors if `max` <= 0
+type errCh struct {
+	errs []*errEntry
+	max  int
+	// merge duplicated errors, see `WithErrorDedup`
+	dedup bool
+	// see `WithErrOverflow`
+	overflow OverflowPolicy
+	dropped  int
+	// sequence number of the latest error
+	seq uint64
+	// see `WithErrSampling`, totals count errors per category
+	sampling *ErrSampling
+	totals   map[string]int
+	// see `ErrStream`, no error is streamed once closed
+	stream *stream[error]
+	closed bool
+	mu     sync.Mutex
+}
 
-const (
-	// not to retry
-	Zero RetryMode = iota
-	// use constant time duration mode to retry
-	Constant
-	// use exponential duration mode to retry
-	Exponential
-)
+// a collected error and how many times it occurred
+type errEntry struct {
+	err   error
+	count int
+	// order and time the error first occurred
+	seq uint64
+	at  time.Time
+}
 
-// use to retry for every func call
-type RetryOption struct {
-	// choose mode to your retry mode
-	Mode RetryMode
-	// only work when choose `Constant` retry mode
-	Interval time.Duration
-	// max retry times
-	MaxRetries int64
+func (e *errCh) put(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.stream != nil {
+		e.stream.push(err)
+	}
+	if !e.sample(err) {
+		return
+	}
+	if e.dedup {
+		for _, entry := range e.errs {
+			if sameError(entry.err, err) {
+				entry.count++
+				return
+			}
+		}
+	}
+	e.seq++
+	entry := &errEntry{err: err, count: 1, seq: e.seq, at: time.Now()}
+	if e.max <= 0 || len(e.errs) < e.max {
+		e.errs = append(e.errs, entry)
+		return
+	}
+	e.overflowPut(entry)
 }
 
-type errCh struct {
-	errs chan error
-	mu   sync.Mutex
+// copy collected errors into a closed channel in the order they occurred
+func (e *errCh) ch() chan error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	errs := make(chan error, len(e.errs))
+	for _, entry := range e.errs {
+		errs <- entry.error()
+	}
+	close(errs)
+	return errs
 }
 
-type group struct {
+// Group is a collection of goroutines working on subtasks of the same overall task
+type Group struct {
+	// see `ID`
+	id      string
 	ctx     context.Context
 	wg      sync.WaitGroup
-	cancel  func()
+	cancel  context.CancelCauseFunc
 	errOnce sync.Once
 	// control whole group's concurrency number
-	sema *semaphore.Weighted
+	sema Limiter
 	// true mean wait all func return
 	waitAll bool
 	err     *errCh
-	// work for every func call
+	// work for every func call, copied from the caller's option and never changed
 	retryMode *RetryOption
+	// run once before any func call, see `WithPreflight`
+	preflight      func(ctx context.Context) error
+	preflightRetry *RetryOption
+	// closed once preflight finished, nil if no preflight
+	ready chan struct{}
+	// true mean preflight failed and no func will be called
+	aborted bool
+	// run once after all funcs returned, see `WithPostflight`
+	postflight func(ctx context.Context, s Summary) error
+	doneOnce   sync.Once
+	// protect `summary` and other bookkeeping
+	mu      sync.Mutex
+	summary Summary
+	// when the group was created
+	start time.Time
+	// funcs returned or never called, and funcs running now, see `Dump`
+	finished     int64
+	running      int64
+	runningTasks map[*task]struct{}
+	// see `Err`
+	firstErr error
+	// nil unless `WithRecordAttemptErrors`
+	attempts *attemptErrs
+	// see `WithRetryDesync`
+	desync bool
+	// run in order once ctx cancelled, see `AfterCancel`, also protect `Finally` callbacks
+	cancelMu   sync.Mutex
+	cancelFns  []func()
+	cancelled  bool
+	cancelDone chan struct{}
+	// optional funcs failed or skipped, see `GoOptional`
+	degradations []Degradation
+	// first panic re-raised by `Wait`, see `WithRepanic`
+	repanic  bool
+	panicked *PanicError
+	// see `WithErrorStacks`
+	stacks bool
+	// see `WithSuppressCanceled`, `failedFast` is 1 once a failed func cancelled the group
+	suppressCanceled bool
+	failedFast       int32
+	causeOnce        sync.Once
+	// latest events, see `WithAuditLog`
+	audit *eventRing
+	// see `WithEventHandler`
+	onEvent []func(e Event)
+	// see `Events`
+	eventsMu     sync.Mutex
+	events       *stream[Event]
+	eventsClosed bool
+	// see `WithHook`
+	hooks []Hook
+	// see `WithLogLevels`
+	logLevels *LogLevels
+	// nil unless `WithDurations`
+	durations []TaskDuration
+	// see `WithTimeline`
+	timeline *timeline
+	// see `WithDebug`, count funcs running per goroutine
+	debug          bool
+	taskGoroutines map[uint64]int
+	// see `WithErrorHandler`
+	onError func(taskName string, err error)
+	// see `WithWrapError`
+	wrapErr func(err error) error
+	// run once after completion, see `Finally`
+	finallyFns  []func()
+	completed   bool
+	finallyRan  bool
+	finallyDone chan struct{}
+	// see `Pressure`
+	pressure pressure
+	// a slot per goroutine running a func, nil mean no limit, see `WithMaxGoroutines`
+	goroutines chan struct{}
+	// funcs waiting for a goroutine, nil unless `WithQueue` or `WithWorkerPool`
+	queue *queue
+	// see `WithLateSubmissionPolicy`
+	late LateSubmissionPolicy
+	// slots per key, nil unless `WithKeyLimit`
+	keys *keyLimiter
+	// see `WithRateLimit`
+	rate RateLimiter
+	// see `WithCircuitBreaker`
+	breaker *breaker
+	// see `WithLoadShedding`
+	shedder *shedder
+	// see `WithMemoryAdmission`
+	memory *memoryAdmission
+	// see `WithAdmitter`
+	admitter Admitter
+	// see `WithTaskTimeout`
+	taskTimeout time.Duration
+	// see `WithCleanupErrors`
+	cleanupErrs bool
+	// undos of succeeded funcs and how many run at a time, see `Compensate`
+	undos     []func(ctx context.Context) error
+	undoLimit int
+	// see `WithStallDetection`, `stalled` count attempts stalled now
+	stallAfter time.Duration
+	onStall    func(name string, idle time.Duration)
+	stalled    int64
+	// see `WithRejectionHandler`
+	onReject func(name string, f func(ctx context.Context) error)
+	// settings passed to `NewGroupWithContext` and funcs failed, see `RetryFailed`
+	params params
+	failed []*task
+	// see `WithParent`
+	parent *Group
+	// sub-pools by name, see `Pool`
+	pools map[string]*Pool
+	// last func passed per key, nil unless `WithLanes`
+	lanes map[string]*task
+	// deps of named funcs, see `After`
+	deps map[string][]string
+	// completion of named funcs, see `DoneCh`
+	doneStates map[string]*doneState
+	doneClosed bool
 }
 
+// Option configure optional behavior of a group
+type Option func(g *Group)
+
 // pass a context to get a new error group
 // `maxConcurrency` define max concurrency during whole errgroup life time
-// `waitAll` stand for two mode: `true` mean error occurs not trigger ctx's cancel function;`false` will trigger once error occurs
-// `retryMode` define three mode of retry: zero, constant, exponential
-// `maxErrs` define max err errgroup will return
-func NewGroupWithContext(ctx context.Context, maxConcurrency int64, waitAll bool, retryMode *RetryOption, maxErrs int) (*group, context.Context) {
-	var (
-		sema *semaphore.Weighted
-		errs *errCh
-	)
-	ctx, cancel := context.WithCancel(ctx)
+// `waitAll` stand for two mode: `true` mean error occurs not trigger ctx's cancel function;`false` will trigger once error occurs,
+// `context.Cause(ctx)` then tell which func failed
+// `retryMode` define three mode of retry: zero, constant, exponential, it's copied so one option can be reused by many groups
+// `maxErrs` define max err errgroup will return, <= 0 mean return all errors
+// `opts` enable optional behaviors, see `Option`
+func NewGroupWithContext(ctx context.Context, maxConcurrency int64, waitAll bool, retryMode *RetryOption, maxErrs int, opts ...Option) (*Group, context.Context) {
+	var sema Limiter
+	ctx, id := withID(ctx)
+	ctx, cancel := context.WithCancelCause(ctx)
 	if maxConcurrency > 0 {
 		sema = semaphore.NewWeighted(maxConcurrency)
 	}
-	if maxErrs > 0 {
-		errs = &errCh{
-			errs: make(chan error, maxErrs),
-			mu:   sync.Mutex{},
+	errs := &errCh{
+		max: maxErrs,
+		mu:  sync.Mutex{},
+	}
+	g := &Group{
+		id:          id,
+		ctx:         ctx,
+		wg:          sync.WaitGroup{},
+		cancel:      cancel,
+		errOnce:     sync.Once{},
+		sema:        sema,
+		pressure:    pressure{max: maxConcurrency},
+		waitAll:     waitAll,
+		err:         errs,
+		retryMode:   retryMode.clone(),
+		cancelDone:  make(chan struct{}),
+		finallyDone: make(chan struct{}),
+		start:       time.Now(),
+		// siblings of the failed func mostly die of cancellation in fail-fast mode
+		suppressCanceled: !waitAll,
+		params: params{
+			maxConcurrency: maxConcurrency,
+			waitAll:        waitAll,
+			retryMode:      retryMode.clone(),
+			maxErrs:        maxErrs,
+			opts:           opts,
+		},
+	}
+	context.AfterFunc(ctx, g.afterCancel)
+	for _, opt := range opts {
+		opt(g)
+	}
+	g.startPreflight()
+	return g, ctx
+}
+
+// record an error due to `maxErrs`
+func (g *Group) putErr(err error) {
+	if g.wrapErr != nil {
+		if err = g.wrapErr(err); err == nil {
+			return
 		}
 	}
-	return &group{
-		ctx:       ctx,
-		wg:        sync.WaitGroup{},
-		cancel:    cancel,
-		errOnce:   sync.Once{},
-		sema:      sema,
-		waitAll:   waitAll,
-		err:       errs,
-		retryMode: retryMode,
-	}, ctx
+	g.mu.Lock()
+	if g.firstErr == nil {
+		g.firstErr = err
+	}
+	g.mu.Unlock()
+	g.err.put(err)
 }
 
-// wait all funcs run over (wait mode due to `waitAll` control) return err channel if `maxErrs` > 0
-func (g *group) Wait() chan error {
+// first error reported so far or nil, like `context.Context.Err` it can be called at any time,
+// before `Wait` included, and not consume errors returned by `Wait`
+func (g *Group) Err() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.firstErr
+}
+
+// wait all funcs run over (wait mode due to `waitAll` control) return err channel holding errors in the order they occurred, see `ErrorRecords`
+// the channel is never nil and closed, so `for err := range g.Wait()` receive all errors and never block
+func (g *Group) Wait() chan error {
+	g.checkWait()
+	g.failUnknownDeps()
 	g.wg.Wait()
-	g.cancel()
-	if g.err != nil {
-		return g.err.errs
+	g.doneOnce.Do(g.complete)
+	g.rethrow()
+	return g.err.ch()
+}
+
+// a func passed to the group with its settings
+type task struct {
+	// used to wrap errors if not empty
+	name string
+	fn   func(ctx context.Context) error
+	// group's retry mode unless set by archetype
+	retryMode *RetryOption
+	// deadline of every attempt, 0 mean no deadline
+	timeout time.Duration
+	// concurrency slots the func takes
+	weight int64
+	// failure is reported as degradation, see `GoOptional`
+	optional bool
+	// stack of the `Go` caller, see `WithErrorStacks`
+	stack []byte
+	// see `IdempotencyKey`
+	key string
+	// see `GoTagged`
+	tags map[string]string
+	// in `failed` of the group once, however many runs failed, see `RetryFailed`
+	rerun bool
+	// passed after ctx of the group was cancelled, see `WithLateSubmissionPolicy`
+	late bool
+	// see `GoKeyed`
+	slotKey string
+	// see `Group.Pool`
+	pool *Pool
+	// closed once the previous func of the lane and this one returned, see `WithLanes`
+	laneWait, laneDone chan struct{}
+	// derived from ctx of the group, cancelled by `Task.Cancel`
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	// wait before start, see `GoAfter`
+	delay time.Duration
+	// run again at this interval, see `GoEvery`
+	every time.Duration
+	// worker of `WithWorkerPool` running the current run, 0 if none, see `WorkerID`
+	worker int
+	// slots of its pool and key taken before it was queued, see `hold`
+	held func()
+	// ctx the func was passed from, see `GoFrom`
+	parent context.Context
+	// group running the func and funcs registered by `OnDone`
+	group    *Group
+	cleanups *cleanups
+	// see `Compensate`
+	undos *undos
+	// see `Heartbeat`
+	heartbeat *heartbeat
+	// ctx attempts of the current run derive from, ctx of the current attempt and how many
+	// attempts were made, see `Hook`
+	runCtx, attemptCtx context.Context
+	attempts           int64
+	// when the func was passed and got its slots, see `Dump`
+	submitted, acquired time.Time
+	// when the current run became due, `submitted` or a tick of `GoEvery`, see `TaskInfo`
+	due time.Time
+	// closed once the func returned or was skipped with `err`, see `Task.Done`
+	finished chan struct{}
+	err      error
+}
+
+// a task with group's settings
+func (g *Group) newTask(name string, fn func(ctx context.Context) error) *task {
+	return &task{
+		name:      name,
+		fn:        fn,
+		retryMode: g.retryMode,
+		weight:    1,
+		timeout:   g.taskTimeout,
+		key:       newIdempotencyKey(),
 	}
-	return nil
 }
 
-// running unit func
-func (g *group) Go(f func() error) {
-	fun := f
-	g.wg.Add(1)
-	if g.retryMode != nil {
-		fun = func() error {
-			var backoffOpt backoff.BackOff
-			switch g.retryMode.Mode {
-			case Zero:
-				backoffOpt = backoff.WithMaxRetries(&backoff.StopBackOff{}, uint64(g.retryMode.MaxRetries))
-			case Constant:
-				backoffOpt = backoff.WithMaxRetries(backoff.NewConstantBackOff(g.retryMode.Interval), uint64(g.retryMode.MaxRetries))
-			case Exponential:
-				backoffOpt = backoff.WithMaxRetries(backoff.NewExponentialBackOff(), uint64(g.retryMode.MaxRetries))
+// adapt funcs not taking ctx
+func withoutCtx(f func() error) func(ctx context.Context) error {
+	return func(context.Context) error {
+		return f()
+	}
+}
+
+// run once by the first `Wait` after all funcs returned
+func (g *Group) complete() {
+	g.runPostflight()
+	g.cancel(nil)
+	<-g.cancelDone
+	g.compensate()
+	g.err.closeStream()
+	g.closeEvents()
+	g.closeDone()
+	if g.queue != nil {
+		g.queue.close()
+	}
+	g.runFinally()
+}
+
+// running unit func, return its handle, see `Task`
+func (g *Group) Go(f func() error) *Task {
+	return g.submit(g.newTask("", withoutCtx(f)))
+}
+
+// running unit func named `name`, its error is wrapped as `task "name": err`, see `TaskError`
+func (g *Group) GoNamed(name string, f func() error) *Task {
+	return g.submit(g.newTask(name, withoutCtx(f)))
+}
+
+// call `t.fn` once with ctx due to task settings
+func (g *Group) attempt(t *task) func() error {
+	return func() error {
+		if t.cancelled() {
+			return backoff.Permanent(context.Cause(t.ctx))
+		}
+		t.undos.reset()
+		ctx := context.WithValue(t.attemptCtx, taskKey{}, t)
+		var timeout error
+		if t.timeout > 0 {
+			var cancel context.CancelFunc
+			timeout = fmt.Errorf("%w: task timeout %v", context.DeadlineExceeded, t.timeout)
+			ctx, cancel = context.WithTimeoutCause(ctx, t.timeout, timeout)
+			defer cancel()
+		}
+		if g.rate != nil {
+			if err := g.rate.Wait(ctx); err != nil {
+				return err
 			}
-			return backoff.Retry(f, backoffOpt)
 		}
+		stop := g.watch(t)
+		start := time.Now()
+		err := withClosers(ctx, t.fn)
+		stop()
+		if a, ok := g.sema.(*adaptiveLimiter); ok {
+			a.observe(time.Since(start), err)
+		}
+		if g.shedder != nil {
+			g.shedder.observe(time.Since(start))
+		}
+		if err != nil && timeout != nil && context.Cause(ctx) == timeout {
+			err = &TimeoutError{Timeout: t.timeout, Err: err}
+		}
+		return err
+	}
+}
 
+func (g *Group) submit(t *task) *Task {
+	notify := func(err error, next time.Duration) {
+		attempt := int(atomic.LoadInt64(&t.attempts))
+		g.emit(Event{Kind: TaskRetried, Task: t.name, Tags: t.tags, Worker: t.worker, Attempt: attempt, Delay: next, Err: err})
+	}
+	fun := retry(countAttempts(t, g.recordAttempts(t, g.guard(g.hook(t, recoverPanic(g.attempt(t)))))), t.retryMode, g.desync, notify)
+	if g.stacks {
+		t.stack = debug.Stack()
+	}
+	t.late = g.ctx.Err() != nil
+	t.submitted = time.Now()
+	t.due = t.submitted
+	t.ctx, t.cancel = context.WithCancelCause(g.ctx)
+	t.finished, t.err = make(chan struct{}), nil
+	t.group, t.cleanups, t.undos = g, &cleanups{}, &undos{}
+	t.heartbeat = &heartbeat{}
+	if t.name != "" {
+		g.mu.Lock()
+		g.doneState(t.name).passed = true
+		g.mu.Unlock()
+	}
+	g.joinLane(t)
+	g.checkSubmit(t)
+	// a func heavier than the whole budget would never get its slots
+	if g.pressure.max > 0 && t.weight > g.pressure.max {
+		t.weight = g.pressure.max
+	}
+	g.count(&g.summary.Submitted)
+	g.emit(Event{Kind: TaskQueued, Task: t.name, Tags: t.tags})
+	g.wg.Add(1)
+	if g.queue != nil {
+		g.enqueue(t, fun)
+		return &Task{t: t}
+	}
+	if g.goroutines != nil {
+		g.goroutines <- struct{}{}
 	}
 	go func() {
-		if g.sema != nil {
-			err := g.sema.Acquire(g.ctx, 1)
-			if err != nil {
-				if g.err != nil {
-					g.err.mu.Lock()
-					if len(g.err.errs)-cap(g.err.errs) > 0 {
-						g.err.errs <- err
-					}
-					g.err.mu.Unlock()
-				}
-				return
+		if g.goroutines != nil {
+			defer func() { <-g.goroutines }()
+		}
+		g.exec(t, fun)
+	}()
+	return &Task{t: t}
+}
+
+// run `t` and report it returned
+func (g *Group) exec(t *task, fun func() (int64, bool, error)) {
+	pprof.SetGoroutineLabels(g.ctx)
+	defer g.wg.Done()
+	defer g.count(&g.finished)
+	defer t.cancel(nil)
+	defer g.enter()()
+	defer g.leaveLane(t)
+	err := g.repeat(t, func() error { return g.runStep(t, fun) })
+	g.cleanup(t)
+	g.done(t, err)
+}
+
+// run `t` in its goroutine, return the error it finally failed or was skipped with
+func (g *Group) run(t *task, fun func() (int64, bool, error)) error {
+	if g.ready != nil {
+		<-g.ready
+		if g.aborted {
+			g.skip(t, g.ctx.Err())
+			return g.ctx.Err()
+		}
+	}
+
+	if t.late && g.late == Reject {
+		return g.reject(t)
+	}
+
+	if err := g.sleep(t); err != nil {
+		if t.optional {
+			g.skip(t, err)
+		} else {
+			g.fail(t, err)
+		}
+		return err
+	}
+
+	if t.laneWait != nil {
+		<-t.laneWait
+	}
+
+	if g.shedder != nil {
+		if err := g.shedder.admit(g.slotCtx(t)); err != nil {
+			if t.optional {
+				g.skip(t, err)
+			} else {
+				g.fail(t, err)
 			}
+			return err
 		}
+		defer g.shedder.done()
+	}
 
-		defer func() {
-			if g.sema != nil {
-				g.sema.Release(1)
+	if g.memory != nil {
+		if err := g.memory.admit(g.slotCtx(t)); err != nil {
+			err = fmt.Errorf("%w: %w", ErrAcquireCancelled, err)
+			if t.optional {
+				g.skip(t, err)
+			} else {
+				g.fail(t, err)
 			}
-			g.wg.Done()
-		}()
-
-		if err := fun(); err != nil {
-			if g.err != nil {
-				g.err.mu.Lock()
-				if len(g.err.errs)-cap(g.err.errs) > 0 {
-					g.err.errs <- err
-				}
-				g.err.mu.Unlock()
+			return err
+		}
+		defer g.memory.done()
+	}
+
+	if err := g.waitDeps(t); err != nil {
+		if t.optional {
+			g.skip(t, err)
+		} else {
+			g.fail(t, err)
+		}
+		return err
+	}
+
+	var wait time.Duration
+	if !(t.late && g.late == RunAnyway) {
+		start := time.Now()
+		release, err := g.acquire(t)
+		wait = time.Since(start)
+		if err != nil {
+			if t.optional {
+				g.skip(t, err)
+				return err
 			}
+			g.fail(t, err)
+			return err
 		}
-		if !g.waitAll {
-			g.errOnce.Do(func() {
-				g.cancel()
-			})
+		defer release()
+	}
+
+	if t.optional && g.ctx.Err() != nil && !(t.late && g.late != Compete) {
+		g.skip(t, g.ctx.Err())
+		return g.ctx.Err()
+	}
+
+	t.acquired = time.Now()
+	end := g.startTask(t, wait)
+	g.emit(Event{Kind: TaskStarted, Task: t.name, Tags: t.tags, Worker: t.worker})
+	g.startRunning(t)
+	start := time.Now()
+	retries, exhausted, err := fun()
+	elapsed := time.Since(start)
+	g.stopRunning(t)
+	end(int(retries)+1, err)
+	g.recordDuration(t, elapsed)
+	g.emit(Event{Kind: TaskFinished, Task: t.name, Tags: t.tags, Worker: t.worker, Duration: elapsed, Err: err})
+	g.countRetries(retries, exhausted, err)
+	if err != nil && t.optional {
+		g.degrade(t, err, false)
+		return err
+	}
+	if err != nil {
+		g.fail(t, err)
+	} else {
+		g.keepUndos(t)
+		g.count(&g.summary.Succeeded)
+	}
+	return err
+}
+
+// wait for slots of `t`, the ones of its pool and key first so it not hold a slot of the
+// group meanwhile, those of the parent and the admitter last, return func to release them
+func (g *Group) acquire(t *task) (func(), error) {
+	ctx := g.slotCtx(t)
+	var releases []func()
+	release := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
 		}
-	}()
+	}
+	if t.held != nil {
+		releases = append(releases, t.held)
+		t.held = nil
+	} else {
+		releaseBulkhead, err := g.acquireBulkhead(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, releaseBulkhead)
+	}
+	if g.sema != nil {
+		g.pressure.enqueue()
+		start := time.Now()
+		err := g.sema.Acquire(ctx, t.weight)
+		g.pressure.dequeue(time.Since(start))
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("%w: %w", ErrAcquireCancelled, err)
+		}
+		start = time.Now()
+		releases = append(releases, func() {
+			g.sema.Release(t.weight)
+			g.pressure.release(time.Since(start))
+		})
+	}
+	if g.parent != nil {
+		releaseParent, err := g.parent.acquireBudget(ctx, t.weight)
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("%w: %w", ErrAcquireCancelled, err)
+		}
+		releases = append(releases, releaseParent)
+	}
+	if g.admitter != nil {
+		if err := g.admitter.Acquire(ctx, t.weight); err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, func() { g.admitter.Release(t.weight) })
+	}
+	return release, nil
+}
+
+// wait for slots of the pool and key of `t`, return func to release them
+func (g *Group) acquireBulkhead(ctx context.Context, t *task) (func(), error) {
+	releasePool := func() {}
+	if t.pool != nil && t.pool.sema != nil {
+		if err := t.pool.sema.Acquire(ctx, 1); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrAcquireCancelled, err)
+		}
+		releasePool = func() { t.pool.sema.Release(1) }
+	}
+	if t.slotKey == "" || g.keys == nil {
+		return releasePool, nil
+	}
+	releaseKey, err := g.keys.acquire(ctx, t.slotKey)
+	if err != nil {
+		releasePool()
+		return nil, fmt.Errorf("%w: %w", ErrAcquireCancelled, err)
+	}
+	return func() {
+		releaseKey()
+		releasePool()
+	}, nil
+}
+
+// report error of a func and cancel the group if not `waitAll`, with a cause naming the func
+func (g *Group) fail(t *task, err error) {
+	if g.dropCancelled(t) {
+		return
+	}
+	g.mu.Lock()
+	if !t.rerun {
+		t.rerun = true
+		g.failed = append(g.failed, t)
+	}
+	g.mu.Unlock()
+	raw := err
+	err = t.wrap(err)
+	if t.stack != nil {
+		err = &StackError{Err: err, Stack: t.stack}
+	}
+	g.catchPanic(err)
+	if !g.suppressCanceledErr(err) {
+		g.putErr(err)
+		if g.onError != nil {
+			g.onError(t.name, raw)
+		}
+	}
+	g.count(&g.summary.Failed)
+	if !g.waitAll {
+		g.errOnce.Do(func() {
+			atomic.StoreInt32(&g.failedFast, 1)
+			g.cancel(cancelCause(t, raw))
+		})
+	}
 }
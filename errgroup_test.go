@@ -135,7 +135,7 @@ func TestZeroGroup(t *testing.T) {
 				firstErr = err
 			}
 
-			if gErr := g.Wait(); len(gErr) > 0 && <-gErr != firstErr {
+			if gErr := g.Wait(); len(gErr) > 0 && !errors.Is(<-gErr, firstErr) {
 				t.Errorf("after %T.Go(func() error { return err }) for err in %v\n"+
 					"g.Wait() = %v; want %v",
 					g, tc.errs[:i+1], err, firstErr)
@@ -172,7 +172,7 @@ func TestWithContext(t *testing.T) {
 			err := err
 			g.Go(func() error { return err })
 		}
-		if err := g.Wait(); len(err) > 0 && <-err != tc.want {
+		if err := g.Wait(); len(err) > 0 && !errors.Is(<-err, tc.want) {
 			t.Errorf("after %T.Go(func() error { return err }) for err in %v\n"+
 				"g.Wait() = %v; want %v",
 				g, tc.errs, err, tc.want)
@@ -191,3 +191,123 @@ func TestWithContext(t *testing.T) {
 		}
 	}
 }
+
+func TestFailFastCancelsOnFailure(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	g, ctx := errgroup.NewGroupWithContext(context.Background(), 0, false, nil, 0)
+	fail := make(chan struct{})
+	g.Go(func() error { return nil })
+	g.Go(func() error {
+		<-fail
+		return errDoom
+	})
+	time.Sleep(10 * time.Millisecond)
+	if ctx.Err() != nil {
+		t.Fatalf("ctx cancelled after a func succeeded; want it cancelled only on failure")
+	}
+	close(fail)
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("ctx not cancelled after a func failed")
+	}
+	g.Wait()
+}
+
+func TestGoNamed(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 2)
+	g.GoNamed("fetch-user", func() error { return errDoom })
+	g.GoNamed("fetch-order", func() error { return nil })
+
+	errs := g.Wait()
+	if len(errs) != 1 {
+		t.Fatalf("g.Wait() returned %d errors; want 1", len(errs))
+	}
+	err := <-errs
+	if want := `task "fetch-user": group_test: doomed`; err.Error() != want {
+		t.Errorf("g.Wait() = %q; want %q", err, want)
+	}
+	if !errors.Is(err, errDoom) {
+		t.Errorf("errors.Is(%v, errDoom) = false; want true", err)
+	}
+}
+
+func TestUnlimitedErrors(t *testing.T) {
+	const n = 100
+
+	for _, maxErrs := range []int{0, -1} {
+		g, _ := errgroup.NewGroupWithContext(context.Background(), 10, true, nil, maxErrs)
+		for i := 0; i < n; i++ {
+			i := i
+			g.Go(func() error { return fmt.Errorf("group_test: %d", i) })
+		}
+		if errs := g.Wait(); len(errs) != n {
+			t.Errorf("maxErrs %d: g.Wait() returned %d errors; want %d", maxErrs, len(errs), n)
+		}
+	}
+}
+
+func TestWaitClosesErrors(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 3)
+	for i := 0; i < 5; i++ {
+		g.Go(func() error { return errDoom })
+	}
+
+	n := 0
+	for err := range g.Wait() {
+		if err != errDoom {
+			t.Errorf("g.Wait() = %v; want %v", err, errDoom)
+		}
+		n++
+	}
+	if n != 3 {
+		t.Errorf("received %d errors; want 3", n)
+	}
+}
+
+func TestWaitNeverNil(t *testing.T) {
+	for _, maxErrs := range []int{0, 1} {
+		g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, maxErrs)
+		g.Go(func() error { return nil })
+		errs := g.Wait()
+		if errs == nil {
+			t.Fatalf("maxErrs %d: g.Wait() returned a nil channel", maxErrs)
+		}
+		for err := range errs {
+			t.Errorf("maxErrs %d: g.Wait() = %v; want no error", maxErrs, err)
+		}
+	}
+}
+
+func TestErr(t *testing.T) {
+	errFirst := errors.New("group_test: first")
+
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0)
+	if err := g.Err(); err != nil {
+		t.Errorf("g.Err() of a new group = %v; want nil", err)
+	}
+	release := make(chan struct{})
+	g.Go(func() error { return errFirst })
+	g.Go(func() error {
+		<-release
+		return errors.New("group_test: second")
+	})
+	for g.Err() == nil {
+		time.Sleep(time.Millisecond)
+	}
+	if err := g.Err(); err != errFirst {
+		t.Errorf("g.Err() before Wait = %v; want %v", err, errFirst)
+	}
+	close(release)
+	if errs := g.Wait(); len(errs) != 2 {
+		t.Errorf("g.Wait() returned %d errors after g.Err(); want 2", len(errs))
+	}
+	if err := g.Err(); err != errFirst {
+		t.Errorf("g.Err() after Wait = %v; want %v", err, errFirst)
+	}
+}
@@ -0,0 +1,85 @@
+// Package errgroupotel trace funcs of error groups with OpenTelemetry, a module of its own so
+// errgroup itself does not depend on OpenTelemetry
+package errgroupotel
+
+import (
+	"context"
+
+	"github.com/FelixSeptem/errgroup"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentation = "github.com/FelixSeptem/errgroup/errgroupotel"
+
+type hook struct {
+	tracer trace.Tracer
+}
+
+// hook starting a span per func, child of the span in ctx of the group, and a child span per
+// attempt, so fan-outs show up in distributed traces, nil `tp` mean the global provider, span of
+// a func passed by `errgroup.GoFrom` is child of the span the func was passed from instead,
+// linked to the span of the group, and its ctx carry baggage of the submitter too, e.g.
+//
+//	g, ctx := errgroup.NewGroup(ctx, errgroup.WithHook(errgroupotel.Hook(nil)))
+func Hook(tp trace.TracerProvider) errgroup.Hook {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return &hook{tracer: tp.Tracer(instrumentation)}
+}
+
+func (h *hook) StartTask(ctx context.Context, info errgroup.TaskInfo) (context.Context, func(int, error)) {
+	name := info.Name
+	if name == "" {
+		name = "errgroup.task"
+	}
+	attrs := []attribute.KeyValue{
+		attribute.String("errgroup.task", info.Name),
+		attribute.Float64("errgroup.slot_wait_seconds", info.Wait.Seconds()),
+	}
+	// tags of the func, see `errgroup.GoTagged`
+	for k, v := range info.Tags {
+		attrs = append(attrs, attribute.String("errgroup.tag."+k, v))
+	}
+	opts := []trace.SpanStartOption{trace.WithAttributes(attrs...)}
+	if info.Parent != nil {
+		if parent := trace.SpanContextFromContext(info.Parent); parent.IsValid() {
+			if group := trace.SpanContextFromContext(ctx); group.IsValid() && !group.Equal(parent) {
+				opts = append(opts, trace.WithLinks(trace.Link{SpanContext: group}))
+			}
+			ctx = trace.ContextWithSpanContext(ctx, parent)
+		}
+		if b := baggage.FromContext(info.Parent); b.Len() > 0 {
+			ctx = baggage.ContextWithBaggage(ctx, b)
+		}
+	}
+	ctx, span := h.tracer.Start(ctx, name, opts...)
+	return ctx, func(attempts int, err error) {
+		span.SetAttributes(attribute.Int("errgroup.attempts", attempts))
+		end(span, err)
+	}
+}
+
+func (h *hook) StartAttempt(ctx context.Context, attempt int) (context.Context, func(error)) {
+	ctx, span := h.tracer.Start(ctx, "errgroup.attempt", trace.WithAttributes(
+		attribute.Int("errgroup.attempt", attempt),
+	))
+	return ctx, func(err error) {
+		end(span, err)
+	}
+}
+
+// end `span` with status due to `err`
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+	span.End()
+}
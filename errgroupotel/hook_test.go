@@ -0,0 +1,110 @@
+package errgroupotel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+	"github.com/FelixSeptem/errgroup/errgroupotel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestHook(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	ctx, root := tp.Tracer("test").Start(context.Background(), "root")
+
+	g, _ := errgroup.NewGroupWithContext(ctx, 1, true,
+		&errgroup.RetryOption{Mode: errgroup.Constant, Interval: time.Millisecond, MaxRetries: 1}, 0,
+		errgroup.WithHook(errgroupotel.Hook(tp)))
+	errFlaky := errors.New("hook_test: flaky")
+	g.GoNamed("fetch", func() error { return errFlaky })
+	g.GoTagged(map[string]string{"shard": "1"}, func(context.Context) error { return nil })
+	g.Wait()
+	root.End()
+
+	spans := map[string][]sdktrace.ReadOnlySpan{}
+	for _, s := range rec.Ended() {
+		spans[s.Name()] = append(spans[s.Name()], s)
+	}
+	if len(spans["fetch"]) != 1 || len(spans["errgroup.task"]) != 1 || len(spans["errgroup.attempt"]) != 3 {
+		t.Fatalf("spans = %v; want 1 fetch span, 1 tagged span and 3 attempt spans", spans)
+	}
+	if tagged := spans["errgroup.task"][0]; !hasAttr(tagged.Attributes(), attribute.String("errgroup.tag.shard", "1")) {
+		t.Errorf("tagged span attributes = %v; want shard 1", tagged.Attributes())
+	}
+	task := spans["fetch"][0]
+	if task.Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Errorf("task span is not a child of the span in ctx of the group")
+	}
+	if task.Status().Code != codes.Error {
+		t.Errorf("task span status = %v; want error", task.Status())
+	}
+	if !hasAttr(task.Attributes(), attribute.Int("errgroup.attempts", 2)) {
+		t.Errorf("task span attributes = %v; want 2 attempts", task.Attributes())
+	}
+	i := 0
+	for _, attempt := range spans["errgroup.attempt"] {
+		if attempt.Parent().SpanID() != task.SpanContext().SpanID() {
+			continue
+		}
+		i++
+		if !hasAttr(attempt.Attributes(), attribute.Int("errgroup.attempt", i)) {
+			t.Errorf("attempt span attributes = %v; want attempt %d", attempt.Attributes(), i)
+		}
+	}
+	if i != 2 {
+		t.Errorf("task span has %d attempt spans; want 2", i)
+	}
+}
+
+func TestHookGoFrom(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	ctx, root := tp.Tracer("test").Start(context.Background(), "root")
+	g, _ := errgroup.NewGroupWithContext(ctx, 1, true, nil, 0, errgroup.WithHook(errgroupotel.Hook(tp)))
+
+	user, _ := baggage.NewMember("user", "42")
+	b, _ := baggage.New(user)
+	submitter, handler := tp.Tracer("test").Start(baggage.ContextWithBaggage(context.Background(), b), "handler")
+	got := ""
+	g.GoFrom(submitter, func(ctx context.Context) error {
+		got = baggage.FromContext(ctx).Member("user").Value()
+		return nil
+	})
+	g.Wait()
+	handler.End()
+	root.End()
+
+	if got != "42" {
+		t.Errorf("baggage member user of func ctx = %q; want 42", got)
+	}
+	for _, s := range rec.Ended() {
+		if s.Name() != "errgroup.task" {
+			continue
+		}
+		if s.Parent().SpanID() != handler.SpanContext().SpanID() {
+			t.Errorf("task span is not a child of the span it was passed from")
+		}
+		if links := s.Links(); len(links) != 1 || links[0].SpanContext.SpanID() != root.SpanContext().SpanID() {
+			t.Errorf("task span links = %v; want a link to the span in ctx of the group", links)
+		}
+		return
+	}
+	t.Fatalf("no task span recorded")
+}
+
+func hasAttr(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, a := range attrs {
+		if a == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+// Package errgroupprom export metrics of error groups to Prometheus, a module of its own so
+// errgroup itself does not depend on Prometheus
+package errgroupprom
+
+import (
+	"sync"
+
+	"github.com/FelixSeptem/errgroup"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is a `prometheus.Collector` over groups passed `Collector.Track`, metrics of groups
+// of the same name are summed, e.g.
+//
+//	c := errgroupprom.NewCollector("app")
+//	prometheus.MustRegister(c)
+//	g, ctx := errgroup.NewGroup(ctx, c.Track("fetch"))
+type Collector struct {
+	// keys of tags labeling funcs, see `errgroup.GoTagged`
+	tags      []string
+	started   *prometheus.CounterVec
+	duration  *prometheus.HistogramVec
+	succeeded *prometheus.Desc
+	failed    *prometheus.Desc
+	retries   *prometheus.Desc
+	queued    *prometheus.Desc
+	running   *prometheus.Desc
+
+	mu sync.Mutex
+	// running groups and totals of completed ones by name
+	live map[*errgroup.Group]string
+	done map[string]errgroup.Stats
+}
+
+// metrics named `namespace_errgroup_*`, labeled by group name, those of funcs started and
+// their durations by name of the func as well, empty for unnamed ones, see `errgroup.GoNamed`,
+// and by values of `tags` of the func, empty for missing ones, see `errgroup.GoTagged`, mind
+// the cardinality of names and tags
+func NewCollector(namespace string, tags ...string) *Collector {
+	labels := []string{"group"}
+	taskLabels := append([]string{"group", "task"}, tags...)
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "errgroup", name), help, labels, nil)
+	}
+	return &Collector{
+		tags: tags,
+		started: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "errgroup",
+			Name:      "tasks_started_total",
+			Help:      "Number of funcs started, a func started again by GoEvery counts every run.",
+		}, taskLabels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "errgroup",
+			Name:      "task_duration_seconds",
+			Help:      "Time funcs ran, retries included.",
+			Buckets:   prometheus.DefBuckets,
+		}, taskLabels),
+		succeeded: desc("tasks_succeeded_total", "Number of funcs returned nil."),
+		failed:    desc("tasks_failed_total", "Number of funcs failed, include those could not start."),
+		retries:   desc("retries_total", "Number of retries made by all funcs."),
+		queued:    desc("tasks_queued", "Number of funcs waiting to start."),
+		running:   desc("tasks_running", "Number of funcs running now."),
+		live:      make(map[*errgroup.Group]string),
+		done:      make(map[string]errgroup.Stats),
+	}
+}
+
+// option collecting metrics of the group labeled `name`, see `errgroup.WithEventHandler`
+func (c *Collector) Track(name string) errgroup.Option {
+	return func(g *errgroup.Group) {
+		errgroup.WithEventHandler(func(e errgroup.Event) {
+			switch e.Kind {
+			case errgroup.TaskStarted:
+				c.started.WithLabelValues(c.values(name, e)...).Inc()
+			case errgroup.TaskFinished:
+				c.duration.WithLabelValues(c.values(name, e)...).Observe(e.Duration.Seconds())
+			}
+		})(g)
+		c.mu.Lock()
+		c.live[g] = name
+		c.mu.Unlock()
+		// keep totals of the group only once it completed
+		g.Finally(func() {
+			s := g.Stats()
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			delete(c.live, g)
+			c.done[name] = add(c.done[name], s)
+		})
+	}
+}
+
+// label values of the func of `e` in group `name`
+func (c *Collector) values(name string, e errgroup.Event) []string {
+	values := []string{name, e.Task}
+	for _, tag := range c.tags {
+		values = append(values, e.Tags[tag])
+	}
+	return values
+}
+
+func add(a, b errgroup.Stats) errgroup.Stats {
+	a.Succeeded += b.Succeeded
+	a.Failed += b.Failed
+	a.Retried += b.Retried
+	a.Queued += b.Queued
+	a.Running += b.Running
+	return a
+}
+
+// Describe implements `prometheus.Collector`
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.started.Describe(ch)
+	c.duration.Describe(ch)
+	ch <- c.succeeded
+	ch <- c.failed
+	ch <- c.retries
+	ch <- c.queued
+	ch <- c.running
+}
+
+// Collect implements `prometheus.Collector`
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.started.Collect(ch)
+	c.duration.Collect(ch)
+	c.mu.Lock()
+	stats := make(map[string]errgroup.Stats, len(c.done))
+	for name, s := range c.done {
+		// completed groups have nothing queued or running
+		stats[name] = errgroup.Stats{Succeeded: s.Succeeded, Failed: s.Failed, Retried: s.Retried}
+	}
+	for g, name := range c.live {
+		stats[name] = add(stats[name], g.Stats())
+	}
+	c.mu.Unlock()
+	for name, s := range stats {
+		ch <- prometheus.MustNewConstMetric(c.succeeded, prometheus.CounterValue, float64(s.Succeeded), name)
+		ch <- prometheus.MustNewConstMetric(c.failed, prometheus.CounterValue, float64(s.Failed), name)
+		ch <- prometheus.MustNewConstMetric(c.retries, prometheus.CounterValue, float64(s.Retried), name)
+		ch <- prometheus.MustNewConstMetric(c.queued, prometheus.GaugeValue, float64(s.Queued), name)
+		ch <- prometheus.MustNewConstMetric(c.running, prometheus.GaugeValue, float64(s.Running), name)
+	}
+}
@@ -0,0 +1,102 @@
+package errgroupprom_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+	"github.com/FelixSeptem/errgroup/errgroupprom"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestCollector(t *testing.T) {
+	c := errgroupprom.NewCollector("test", "shard")
+	reg := prometheus.NewPedanticRegistry()
+	reg.MustRegister(c)
+
+	for i := 0; i < 2; i++ {
+		g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0, c.Track("fetch"))
+		g.GoNamed("page", func() error { return nil })
+		g.GoTagged(map[string]string{"shard": "1"}, func(context.Context) error { return nil })
+		g.Go(func() error { return errors.New("collector_test: failed") })
+		g.Wait()
+	}
+	running, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0, c.Track("fetch"))
+	started, release := make(chan struct{}), make(chan struct{})
+	running.Go(func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	defer func() {
+		close(release)
+		running.Wait()
+	}()
+	<-started
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("reg.Gather() = %v", err)
+	}
+	values := map[string]float64{}
+	pages, shards := 0.0, 0.0
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			if label := m.GetLabel(); len(label) == 0 || label[0].GetName() != "group" || label[0].GetValue() != "fetch" {
+				t.Errorf("%s labels = %v; want group=fetch", mf.GetName(), label)
+			}
+			if mf.GetName() == "test_errgroup_tasks_started_total" {
+				if label(m, "task") == "page" {
+					pages = value(m)
+				}
+				if label(m, "shard") == "1" {
+					shards = value(m)
+				}
+			}
+			values[mf.GetName()] += value(m)
+		}
+	}
+	if pages != 2 {
+		t.Errorf("started funcs named page = %v; want 2", pages)
+	}
+	if shards != 2 {
+		t.Errorf("started funcs of shard 1 = %v; want 2", shards)
+	}
+	want := map[string]float64{
+		"test_errgroup_tasks_started_total":   7,
+		"test_errgroup_task_duration_seconds": 6,
+		"test_errgroup_tasks_succeeded_total": 4,
+		"test_errgroup_tasks_failed_total":    2,
+		"test_errgroup_retries_total":         0,
+		"test_errgroup_tasks_queued":          0,
+		"test_errgroup_tasks_running":         1,
+	}
+	for name, v := range want {
+		if values[name] != v {
+			t.Errorf("%s = %v; want %v", name, values[name], v)
+		}
+	}
+}
+
+func label(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+func value(m *dto.Metric) float64 {
+	switch {
+	case m.Counter != nil:
+		return m.Counter.GetValue()
+	case m.Gauge != nil:
+		return m.Gauge.GetValue()
+	case m.Histogram != nil:
+		return float64(m.Histogram.GetSampleCount())
+	}
+	return 0
+}
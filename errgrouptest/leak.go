@@ -0,0 +1,61 @@
+// Package errgrouptest provide helpers to test code using error groups
+package errgrouptest
+
+import (
+	"bytes"
+	"fmt"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+// how long goroutines of the group are given to exit, see `VerifyNoLeaks`
+var Timeout = time.Second
+
+// fail `t` if goroutines running funcs of `g`, or started by them, not exited shortly after
+// `Wait` returned, to catch funcs ignoring cancellation in CI, goroutines are told apart by the
+// pprof label `errgroup.PprofLabel`, the stacks of leaked ones are reported
+func VerifyNoLeaks(t testing.TB, g *errgroup.Group) {
+	t.Helper()
+	deadline := time.Now().Add(Timeout)
+	for {
+		n, stacks := leaked(g.ID())
+		if n == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Errorf("errgrouptest: %d goroutines of group %s not exited after %v:\n%s", n, g.ID(), Timeout, stacks)
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// count goroutines labeled with group `id` and dump their stacks
+func leaked(id string) (int, string) {
+	var buf bytes.Buffer
+	pprof.Lookup("goroutine").WriteTo(&buf, 1)
+	label := fmt.Sprintf("%q:%q", errgroup.PprofLabel, id)
+	n := 0
+	var stacks strings.Builder
+	// skip the header and split records of the legacy text format
+	_, records, _ := strings.Cut(buf.String(), "\n")
+	for _, block := range strings.Split(records, "\n\n") {
+		lines := strings.SplitN(block, "\n", 3)
+		if len(lines) < 2 || !strings.HasPrefix(lines[1], "# labels: ") || !strings.Contains(lines[1], label) {
+			continue
+		}
+		count, err := strconv.Atoi(strings.Fields(lines[0])[0])
+		if err != nil {
+			continue
+		}
+		n += count
+		stacks.WriteString(block)
+		stacks.WriteString("\n\n")
+	}
+	return n, stacks.String()
+}
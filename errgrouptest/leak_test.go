@@ -0,0 +1,76 @@
+package errgrouptest_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+	"github.com/FelixSeptem/errgroup/errgrouptest"
+)
+
+// record failures instead of failing the test
+type recorder struct {
+	testing.TB
+	errs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+func TestVerifyNoLeaks(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, false, nil, 0)
+	for i := 0; i < 3; i++ {
+		g.GoContext(func(ctx context.Context) error {
+			done := make(chan struct{})
+			go func() {
+				<-ctx.Done()
+				close(done)
+			}()
+			<-done
+			return nil
+		})
+	}
+	g.Go(func() error {
+		return fmt.Errorf("failed")
+	})
+	g.Wait()
+	errgrouptest.VerifyNoLeaks(t, g)
+}
+
+func TestVerifyNoLeaksLeaked(t *testing.T) {
+	defer func(timeout time.Duration) {
+		errgrouptest.Timeout = timeout
+	}(errgrouptest.Timeout)
+	errgrouptest.Timeout = 50 * time.Millisecond
+	release := make(chan struct{})
+	defer close(release)
+	g, _ := errgroup.NewGroup(context.Background())
+	other, _ := errgroup.NewGroup(context.Background())
+	g.Go(func() error {
+		go func() {
+			<-release
+		}()
+		return nil
+	})
+	other.Go(func() error {
+		return nil
+	})
+	g.Wait()
+	other.Wait()
+	r := &recorder{TB: t}
+	errgrouptest.VerifyNoLeaks(r, g)
+	if len(r.errs) != 1 || !strings.Contains(r.errs[0], "1 goroutines of group "+g.ID()) {
+		t.Fatalf("unexpected errors: %v", r.errs)
+	}
+	r = &recorder{TB: t}
+	errgrouptest.VerifyNoLeaks(r, other)
+	if len(r.errs) != 0 {
+		t.Fatalf("unexpected errors: %v", r.errs)
+	}
+}
@@ -0,0 +1,27 @@
+package errgroup
+
+import "errors"
+
+// errors reported by the group itself, check with `errors.Is`, the cause is wrapped as well
+var (
+	// func passed after ctx of the group was cancelled with the `Reject` policy, funcs passed
+	// after `Wait` returned are such late funcs too, reported by the next `Wait` only
+	// and never waited for otherwise, see `WithLateSubmissionPolicy`
+	ErrGroupClosed = errors.New("errgroup: group closed")
+	// func rejected as too many funcs are waiting to run
+	ErrQueueFull = errors.New("errgroup: queue full")
+	// func never called as ctx was cancelled while waiting for a concurrency slot
+	ErrAcquireCancelled = errors.New("errgroup: acquire cancelled")
+	// func still failed after all retries of `RetryOption.MaxRetries` were used
+	ErrRetriesExhausted = errors.New("errgroup: retries exhausted")
+	// group ran out of time before all funcs returned
+	ErrGroupTimeout = errors.New("errgroup: group timeout")
+	// func not started as the downstream is too slow, see `WithLoadShedding`
+	ErrOverloaded = errors.New("errgroup: overloaded")
+	// attempt not made as too many attempts failed in a row, see `WithCircuitBreaker`
+	ErrCircuitOpen = errors.New("errgroup: circuit open")
+	// func never called as a func it runs after failed, see `After`
+	ErrDependencyFailed = errors.New("errgroup: dependency failed")
+	// func aborted by `Task.Cancel`
+	ErrTaskCancelled = errors.New("errgroup: task cancelled")
+)
@@ -0,0 +1,71 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+	"github.com/cenkalti/backoff"
+)
+
+func TestErrRetriesExhausted(t *testing.T) {
+	errBusy := errors.New("errors_test: busy")
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, &errgroup.RetryOption{
+		Mode:       errgroup.Constant,
+		Interval:   time.Millisecond,
+		MaxRetries: 2,
+	}, 0)
+	g.Go(func() error { return errBusy })
+	g.Go(func() error { return backoff.Permanent(errBusy) })
+
+	errs := g.Wait()
+	if len(errs) != 2 {
+		t.Fatalf("g.Wait() returned %d errors; want 2", len(errs))
+	}
+	exhausted := 0
+	for err := range errs {
+		if !errors.Is(err, errBusy) {
+			t.Errorf("g.Wait() = %v; want it wrapping %v", err, errBusy)
+		}
+		if errors.Is(err, errgroup.ErrRetriesExhausted) {
+			exhausted++
+		}
+	}
+	if exhausted != 1 {
+		t.Errorf("%d errors are errgroup.ErrRetriesExhausted; want 1", exhausted)
+	}
+}
+
+func TestErrAcquireCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, _ := errgroup.NewGroupWithContext(ctx, 1, true, nil, 0)
+	started, release := make(chan struct{}), make(chan struct{})
+	g.Go(func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+	g.Go(func() error { return nil })
+	for level := range g.Pressure() {
+		if level != errgroup.Low {
+			break
+		}
+	}
+	cancel()
+	for g.Summary().Failed == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+
+	errs := g.Wait()
+	if len(errs) != 1 {
+		t.Fatalf("g.Wait() returned %d errors; want 1", len(errs))
+	}
+	err := <-errs
+	if !errors.Is(err, errgroup.ErrAcquireCancelled) || !errors.Is(err, context.Canceled) {
+		t.Errorf("g.Wait() = %v; want errgroup.ErrAcquireCancelled wrapping context.Canceled", err)
+	}
+}
@@ -0,0 +1,19 @@
+package errgroup
+
+import "time"
+
+// estimate time until all funcs passed so far return, due to the rate funcs returned since
+// the group was created, false if no func returned yet so there is no rate to estimate by
+func (g *Group) ETA() (time.Duration, bool) {
+	g.mu.Lock()
+	submitted, finished := g.summary.Submitted, g.finished
+	g.mu.Unlock()
+	if submitted == finished {
+		return 0, true
+	}
+	if finished == 0 {
+		return 0, false
+	}
+	perFunc := time.Since(g.start) / time.Duration(finished)
+	return perFunc * time.Duration(submitted-finished), true
+}
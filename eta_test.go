@@ -0,0 +1,48 @@
+package errgroup_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestETA(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0)
+	if eta, ok := g.ETA(); !ok || eta != 0 {
+		t.Errorf("g.ETA() of an empty group = %v, %v; want 0, true", eta, ok)
+	}
+
+	release := make(chan struct{})
+	g.Go(func() error {
+		<-release
+		return nil
+	})
+	if _, ok := g.ETA(); ok {
+		t.Errorf("g.ETA() before any func returned is ok; want not ok")
+	}
+	close(release)
+
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		g.Go(func() error {
+			select {
+			case <-done:
+			case <-time.After(time.Millisecond * 10):
+			}
+			return nil
+		})
+	}
+	for g.Summary().Succeeded < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	if eta, ok := g.ETA(); !ok || eta <= 0 || eta > time.Second {
+		t.Errorf("g.ETA() with 3 funcs left = %v, %v; want a positive estimate", eta, ok)
+	}
+	close(done)
+	g.Wait()
+	if eta, ok := g.ETA(); !ok || eta != 0 {
+		t.Errorf("g.ETA() after Wait = %v, %v; want 0, true", eta, ok)
+	}
+}
@@ -0,0 +1,174 @@
+package errgroup
+
+import (
+	"sync"
+	"time"
+)
+
+type EventKind uint8
+
+const (
+	// a func is passed to the group
+	TaskQueued EventKind = iota
+	// a func got its concurrency slot and starts
+	TaskStarted
+	// an attempt of a func failed and it will be retried after `Delay`
+	TaskRetried
+	// a func returned, `Err` is set if it failed
+	TaskFinished
+	// ctx of the group is cancelled
+	GroupCancelled
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case TaskQueued:
+		return "task queued"
+	case TaskStarted:
+		return "task started"
+	case TaskRetried:
+		return "task retried"
+	case TaskFinished:
+		return "task finished"
+	default:
+		return "group cancelled"
+	}
+}
+
+// Event is a lifecycle event of a group or one of its funcs
+type Event struct {
+	Kind EventKind
+	Time time.Time
+	// name of the func, empty for unnamed funcs and group events
+	Task string
+	// see `GoTagged`, must not be modified
+	Tags map[string]string
+	// see `WorkerID`, 0 if not run by a worker of `WithWorkerPool`, only set for `TaskStarted`,
+	// `TaskRetried` and `TaskFinished`
+	Worker int
+	// attempt failed, only set for `TaskRetried`
+	Attempt int
+	// wait before next attempt, only set for `TaskRetried`
+	Delay time.Duration
+	// time the func ran, retries included, only set for `TaskFinished`
+	Duration time.Duration
+	Err      error
+}
+
+// keep the latest events in a fixed size ring
+type eventRing struct {
+	mu     sync.Mutex
+	events []Event
+	next   int
+	full   bool
+}
+
+func (r *eventRing) add(e Event) {
+	r.mu.Lock()
+	r.events[r.next] = e
+	r.next = (r.next + 1) % len(r.events)
+	if r.next == 0 {
+		r.full = true
+	}
+	r.mu.Unlock()
+}
+
+// events in the ring, oldest first
+func (r *eventRing) list() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.full {
+		return append([]Event(nil), r.events[:r.next]...)
+	}
+	return append(append([]Event(nil), r.events[r.next:]...), r.events[:r.next]...)
+}
+
+// keep the last `n` lifecycle events (cancellations, retries, funcs start and finish) in memory,
+// get them by `Snapshot`, so recent history is there when a group misbehaves in production
+// without having had logging enabled
+func WithAuditLog(n int) Option {
+	return func(g *Group) {
+		if n > 0 {
+			g.audit = &eventRing{events: make([]Event, n)}
+		}
+	}
+}
+
+// call `fn` synchronously once a func finally failed (after retries), so services can log or
+// alert at once instead of waiting for `Wait`, `taskName` is empty for unnamed funcs
+// errors suppressed by `WithSuppressCanceled` are not passed
+func WithErrorHandler(fn func(taskName string, err error)) Option {
+	return func(g *Group) {
+		g.onError = fn
+	}
+}
+
+// call `fn` synchronously with every lifecycle event of the group, so metrics and tracing can
+// be plugged in, handlers are called in the order they were passed and must not block
+func WithEventHandler(fn func(e Event)) Option {
+	return func(g *Group) {
+		g.onEvent = append(g.onEvent, fn)
+	}
+}
+
+// Snapshot is the state of a group at some point
+type Snapshot struct {
+	Summary Summary
+	// latest events, oldest first, empty unless `WithAuditLog` is used
+	Events []Event
+}
+
+// state of the group at the moment, can be called at any time
+func (g *Group) Snapshot() Snapshot {
+	s := Snapshot{Summary: g.Summary()}
+	if g.audit != nil {
+		s.Events = g.audit.list()
+	}
+	return s
+}
+
+// channel delivering lifecycle events occurred after the first call as they occur, so
+// supervisors and UIs can follow progress without polling, every call return the same
+// channel, it's closed once the group completed, see `Wait`, it must be drained to release
+// resources, events are buffered instead of blocking funcs
+func (g *Group) Events() <-chan Event {
+	g.eventsMu.Lock()
+	defer g.eventsMu.Unlock()
+	if g.events == nil {
+		g.events = newStream[Event](nil)
+		if g.eventsClosed {
+			g.events.close()
+		}
+	}
+	return g.events.ch
+}
+
+// stop delivering events once the group completed
+func (g *Group) closeEvents() {
+	g.eventsMu.Lock()
+	defer g.eventsMu.Unlock()
+	g.eventsClosed = true
+	if g.events != nil {
+		g.events.close()
+	}
+}
+
+// publish an event of the group
+func (g *Group) emit(e Event) {
+	g.eventsMu.Lock()
+	events := g.events
+	g.eventsMu.Unlock()
+	if g.audit == nil && g.onEvent == nil && events == nil {
+		return
+	}
+	e.Time = time.Now()
+	if g.audit != nil {
+		g.audit.add(e)
+	}
+	if events != nil {
+		events.push(e)
+	}
+	for _, fn := range g.onEvent {
+		fn(e)
+	}
+}
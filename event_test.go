@@ -0,0 +1,144 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestAuditLog(t *testing.T) {
+	errFlaky := errors.New("event_test: flaky")
+
+	g, _ := errgroup.NewGroupWithContext(
+		context.Background(),
+		1,
+		true,
+		&errgroup.RetryOption{
+			Mode:       errgroup.Constant,
+			Interval:   time.Millisecond,
+			MaxRetries: 1,
+		},
+		0,
+		errgroup.WithAuditLog(4))
+	fails := 1
+	g.GoNamed("flaky", func() error {
+		if fails > 0 {
+			fails--
+			return errFlaky
+		}
+		return nil
+	})
+	g.Wait()
+
+	snapshot := g.Snapshot()
+	var kinds []errgroup.EventKind
+	for _, e := range snapshot.Events {
+		kinds = append(kinds, e.Kind)
+	}
+	// the ring of 4 dropped the oldest TaskQueued event
+	want := []errgroup.EventKind{errgroup.TaskStarted, errgroup.TaskRetried, errgroup.TaskFinished, errgroup.GroupCancelled}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("audit log = %v; want %v", kinds, want)
+	}
+	retried := snapshot.Events[1]
+	if retried.Task != "flaky" || retried.Attempt != 1 || retried.Delay != time.Millisecond || retried.Err != errFlaky {
+		t.Errorf("retry event = %+v; want attempt 1 of flaky failed with %v", retried, errFlaky)
+	}
+	if snapshot.Summary.Succeeded != 1 {
+		t.Errorf("snapshot summary = %+v; want 1 succeeded", snapshot.Summary)
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	errDoom := errors.New("event_test: doomed")
+
+	var (
+		mu  sync.Mutex
+		got = map[string]error{}
+	)
+	release := make(chan struct{})
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0,
+		errgroup.WithErrorHandler(func(name string, err error) {
+			mu.Lock()
+			got[name] = err
+			mu.Unlock()
+			if name == "first" {
+				close(release)
+			}
+		}))
+	g.GoNamed("first", func() error { return errDoom })
+	g.GoNamed("second", func() error {
+		// the handler runs before Wait
+		<-release
+		return nil
+	})
+	g.Wait()
+
+	if want := map[string]error{"first": errDoom}; !reflect.DeepEqual(got, want) {
+		t.Errorf("error handler got %v; want %v", got, want)
+	}
+}
+
+func TestEventHandler(t *testing.T) {
+	var mu sync.Mutex
+	var kinds []errgroup.EventKind
+	var duration time.Duration
+	handler := func(e errgroup.Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		kinds = append(kinds, e.Kind)
+		if e.Kind == errgroup.TaskFinished {
+			duration = e.Duration
+		}
+	}
+	var calls int
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0,
+		errgroup.WithEventHandler(handler),
+		errgroup.WithEventHandler(func(errgroup.Event) { calls++ }))
+	g.Go(func() error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+	g.Wait()
+	want := []errgroup.EventKind{errgroup.TaskQueued, errgroup.TaskStarted, errgroup.TaskFinished, errgroup.GroupCancelled}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("events = %v; want %v", kinds, want)
+	}
+	if calls != len(want) {
+		t.Errorf("second handler called %d times; want %d", calls, len(want))
+	}
+	if duration < 10*time.Millisecond {
+		t.Errorf("TaskFinished.Duration = %v; want >= 10ms", duration)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	events := g.Events()
+	if g.Events() != events {
+		t.Errorf("g.Events() returned another channel")
+	}
+	var kinds []errgroup.EventKind
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for e := range events {
+			kinds = append(kinds, e.Kind)
+		}
+	}()
+	g.GoNamed("task", func() error { return nil })
+	g.Wait()
+	<-done
+	want := []errgroup.EventKind{errgroup.TaskQueued, errgroup.TaskStarted, errgroup.TaskFinished, errgroup.GroupCancelled}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("events = %v; want %v", kinds, want)
+	}
+	if _, ok := <-g.Events(); ok {
+		t.Errorf("g.Events() not closed after the group completed")
+	}
+}
@@ -0,0 +1,60 @@
+package errgroup
+
+import (
+	"context"
+	"runtime/pprof"
+	"time"
+)
+
+// running unit func at once and then every `interval` until ctx of the group is cancelled or
+// the returned task is cancelled, like a background ticker loop of a service, every run take
+// its slot or worker of `WithQueue`, is retried and report its error like any other func, the
+// loop itself takes no worker, runs overrunning `interval`
+// drop ticks instead of piling up, `Wait` not return until the loop stopped and `Task.Err`
+// report error of the last run
+func (g *Group) GoEvery(interval time.Duration, f func(ctx context.Context) error) *Task {
+	t := g.newTask("", f)
+	t.every = interval
+	return g.submit(t)
+}
+
+// call `run` due to `t.every`, return error of the last call
+func (g *Group) repeat(t *task, run func() error) error {
+	err := run()
+	if t.every <= 0 {
+		return err
+	}
+	ticker := time.NewTicker(t.every)
+	defer ticker.Stop()
+	for {
+		select {
+		case due := <-ticker.C:
+			if t.ctx.Err() != nil {
+				return err
+			}
+			t.due = due
+			err = run()
+		case <-t.ctx.Done():
+			return err
+		}
+	}
+}
+
+// run `t` once, on a worker of the queue if any as the ticker loop of `GoEvery` runs out of the
+// queue, so it not hold a worker for the lifetime of the group
+func (g *Group) runStep(t *task, fun func() (int64, bool, error)) error {
+	if g.queue == nil || t.every <= 0 {
+		return g.run(t, fun)
+	}
+	step := make(chan error, 1)
+	g.hold(t)
+	g.queue.push(g, job{t: t, fun: fun, step: step})
+	return <-step
+}
+
+// run a queued run of `t`, see `runStep`
+func (g *Group) step(t *task, fun func() (int64, bool, error)) error {
+	pprof.SetGoroutineLabels(g.ctx)
+	defer g.enter()()
+	return g.run(t, fun)
+}
@@ -0,0 +1,76 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestGoEvery(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	g, _ := errgroup.NewGroupWithContext(parent, 1, true, nil, 0)
+	errTick := errors.New("every_test: tick failed")
+	var runs int32
+	g.GoEvery(5*time.Millisecond, func(ctx context.Context) error {
+		if atomic.AddInt32(&runs, 1) == 3 {
+			return errTick
+		}
+		return nil
+	})
+	time.AfterFunc(50*time.Millisecond, cancel)
+	errs := g.Wait()
+	if n := atomic.LoadInt32(&runs); n < 4 {
+		t.Errorf("func ran %d times; want at least 4", n)
+	}
+	if len(errs) != 1 || !errors.Is(<-errs, errTick) {
+		t.Errorf("g.Wait() want only %v", errTick)
+	}
+}
+
+func TestGoEveryCancel(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	var runs int32
+	task := g.GoEvery(5*time.Millisecond, func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	})
+	time.Sleep(20 * time.Millisecond)
+	task.Cancel()
+	<-task.Done()
+	n := atomic.LoadInt32(&runs)
+	time.Sleep(20 * time.Millisecond)
+	if atomic.LoadInt32(&runs) != n {
+		t.Errorf("func ran after the task was cancelled")
+	}
+	g.Wait()
+	if s := g.Summary(); s.Submitted != 1 || s.Succeeded != int64(n) {
+		t.Errorf("g.Summary() = %+v; want 1 submitted, %d succeeded", s, n)
+	}
+}
+
+func TestGoEveryQueued(t *testing.T) {
+	for _, opt := range []errgroup.Option{errgroup.WithQueue(10), errgroup.WithWorkerPool()} {
+		parent, cancel := context.WithCancel(context.Background())
+		g, _ := errgroup.NewGroupWithContext(parent, 1, true, nil, 0, opt)
+		var runs int32
+		g.GoEvery(5*time.Millisecond, func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		})
+		// the ticker loop must not hold the only worker
+		select {
+		case <-g.Go(func() error { return nil }).Done():
+		case <-time.After(time.Second):
+			t.Fatalf("func never ran beside GoEvery")
+		}
+		for atomic.LoadInt32(&runs) < 3 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+		g.Wait()
+	}
+}
@@ -0,0 +1,24 @@
+package errgroup
+
+import (
+	"expvar"
+	"sync"
+)
+
+// map of groups published by `WithExpvar`
+var (
+	expvarOnce sync.Once
+	expvarMap  *expvar.Map
+)
+
+// publish `Stats` of the group as `name` under the "errgroup" expvar map, so services exposing
+// /debug/vars see it without other dependencies, a later group of the same name replace the
+// previous one, which suits a long-lived group or the latest of a recurring batch
+func WithExpvar(name string) Option {
+	return func(g *Group) {
+		expvarOnce.Do(func() {
+			expvarMap = expvar.NewMap("errgroup")
+		})
+		expvarMap.Set(name, expvar.Func(func() interface{} { return g.Stats() }))
+	}
+}
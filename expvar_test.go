@@ -0,0 +1,28 @@
+package errgroup_test
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestWithExpvar(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0, errgroup.WithExpvar("expvar_test"))
+	g.Go(func() error { return nil })
+	g.Wait()
+
+	v := expvar.Get("errgroup").(*expvar.Map).Get("expvar_test")
+	if v == nil {
+		t.Fatalf("group not published")
+	}
+	var s errgroup.Stats
+	if err := json.Unmarshal([]byte(v.String()), &s); err != nil {
+		t.Fatalf("json.Unmarshal(%s) = %v", v, err)
+	}
+	if s.Submitted != 1 || s.Succeeded != 1 {
+		t.Errorf("published stats = %+v; want 1 submitted, 1 succeeded", s)
+	}
+}
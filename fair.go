@@ -0,0 +1,78 @@
+package errgroup
+
+// dispatch queued funcs round-robin across keys of `GoKeyed`, so a burst of funcs of one key
+// not starve other keys waiting in the queue, funcs of a key run due to `WithDispatchOrder`,
+// funcs are kept in the queue of `WithQueue` (unbounded unless set), but those waiting for a
+// slot of their key due to `WithKeyLimit` wait out of it, so a slow key takes no worker other
+// keys need, ignored if `maxConcurrency` <= 0
+func WithFairKeys() Option {
+	return func(g *Group) {
+		if q := g.useQueue(); q != nil {
+			q.fair = true
+		}
+	}
+}
+
+// queued funcs per key, keys take turns
+type fairJobs struct {
+	keys map[string]*jobSlice
+	// keys having queued funcs, the first one is next
+	ring []string
+	n    int
+	lifo bool
+}
+
+func (f *fairJobs) add(j job) {
+	key := j.t.slotKey
+	s, ok := f.keys[key]
+	if !ok {
+		s = &jobSlice{lifo: f.lifo}
+		f.keys[key] = s
+		f.ring = append(f.ring, key)
+	}
+	s.add(j)
+	f.n++
+}
+
+func (f *fairJobs) next() job {
+	key := f.ring[0]
+	f.ring = f.ring[1:]
+	j := f.keys[key].next()
+	if f.keys[key].len() > 0 {
+		f.ring = append(f.ring, key)
+	}
+	f.drop(key)
+	return j
+}
+
+func (f *fairJobs) oldest() job {
+	key := f.ring[0]
+	for _, k := range f.ring[1:] {
+		if f.keys[k].jobs[0].seq < f.keys[key].jobs[0].seq {
+			key = k
+		}
+	}
+	j := f.keys[key].oldest()
+	if f.keys[key].len() == 0 {
+		for i, k := range f.ring {
+			if k == key {
+				f.ring = append(f.ring[:i], f.ring[i+1:]...)
+				break
+			}
+		}
+	}
+	f.drop(key)
+	return j
+}
+
+// count a removed func of `key`, forget the key once it has none
+func (f *fairJobs) drop(key string) {
+	f.n--
+	if f.keys[key].len() == 0 {
+		delete(f.keys, key)
+	}
+}
+
+func (f *fairJobs) len() int {
+	return f.n
+}
@@ -0,0 +1,71 @@
+package errgroup_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestFairKeys(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0, errgroup.WithFairKeys())
+	started, release := make(chan struct{}), make(chan struct{})
+	g.Go(func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+
+	var got []string
+	record := func(s string) func() error {
+		return func() error {
+			got = append(got, s)
+			return nil
+		}
+	}
+	// a burst of one key before the others
+	for _, s := range []string{"a1", "a2", "a3", "a4"} {
+		g.GoKeyed("a", record(s))
+	}
+	g.GoKeyed("b", record("b1"))
+	g.GoKeyed("c", record("c1"))
+	g.GoKeyed("b", record("b2"))
+	close(release)
+	g.Wait()
+
+	want := []string{"a1", "b1", "c1", "a2", "b2", "a3", "a4"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("funcs ran in %v; want %v", got, want)
+	}
+}
+
+func TestFairKeysLimited(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 4, true, nil, 0,
+		errgroup.WithQueue(100), errgroup.WithKeyLimit(1), errgroup.WithFairKeys())
+	// funcs of key a waiting for its only slot must not take the workers b needs
+	release := make(chan struct{})
+	for i := 0; i < 10; i++ {
+		g.GoKeyed("a", func() error {
+			<-release
+			return nil
+		})
+	}
+	g.GoKeyed("b", func() error {
+		close(release)
+		return nil
+	})
+	done := make(chan struct{})
+	go func() {
+		g.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		close(release)
+		t.Fatalf("func of key b waits for funcs of key a")
+	}
+}
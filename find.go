@@ -0,0 +1,48 @@
+package errgroup
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrNotFound is returned by `Find` if no func found a result
+var ErrNotFound = errors.New("errgroup: not found")
+
+// call `fns` with at most `limit` running at a time and return the result of the first one
+// reporting found, ctx passed to the others is cancelled at once, like searching across shards
+// where the first hit suffices, errors are returned only if nothing found, the first one that
+// occurred, or `ErrNotFound` if no func failed
+func Find[T any](ctx context.Context, limit int64, fns []func(ctx context.Context) (T, bool, error)) (T, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	g, ctx := NewGroupWithContext(ctx, limit, true, nil, 1)
+
+	var (
+		once   sync.Once
+		result T
+		found  bool
+	)
+	for _, fn := range fns {
+		fn := fn
+		g.submit(g.newTask("", func(ctx context.Context) error {
+			v, ok, err := fn(ctx)
+			if ok {
+				once.Do(func() {
+					result, found = v, true
+					cancel()
+				})
+				return nil
+			}
+			return err
+		}))
+	}
+	g.Wait()
+	if found {
+		return result, nil
+	}
+	if err := g.Err(); err != nil {
+		return result, err
+	}
+	return result, ErrNotFound
+}
@@ -0,0 +1,38 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestFind(t *testing.T) {
+	errShard := errors.New("find_test: shard down")
+	miss := func(context.Context) (string, bool, error) { return "", false, nil }
+	down := func(context.Context) (string, bool, error) { return "", false, errShard }
+	hit := func(context.Context) (string, bool, error) { return "hit", true, nil }
+	slow := func(ctx context.Context) (string, bool, error) {
+		<-ctx.Done()
+		return "", false, ctx.Err()
+	}
+
+	cases := []struct {
+		fns  []func(context.Context) (string, bool, error)
+		want string
+		err  error
+	}{
+		{fns: nil, err: errgroup.ErrNotFound},
+		{fns: []func(context.Context) (string, bool, error){miss, miss}, err: errgroup.ErrNotFound},
+		{fns: []func(context.Context) (string, bool, error){miss, down}, err: errShard},
+		{fns: []func(context.Context) (string, bool, error){slow, down, hit}, want: "hit"},
+	}
+
+	for i, tc := range cases {
+		got, err := errgroup.Find(context.Background(), 2, tc.fns)
+		if got != tc.want || err != tc.err {
+			t.Errorf("case %d: Find() = %q, %v; want %q, %v", i, got, err, tc.want, tc.err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package errgroup
+
+import "context"
+
+// QueueFullPolicy define what `Go` does once the queue of `WithQueue` is full
+type QueueFullPolicy uint8
+
+const (
+	// block until a queued func starts
+	Block QueueFullPolicy = iota
+	// reject the func passed
+	RejectNewest
+	// reject the oldest queued func to queue the func passed
+	RejectOldest
+)
+
+// choose what `Go` does once the queue is full, default `Block`, rejected funcs are never
+// called and fail with `ErrQueueFull` unless `WithRejectionHandler`
+func WithQueueFullPolicy(policy QueueFullPolicy) Option {
+	return func(g *Group) {
+		if q := g.useQueue(); q != nil {
+			q.full = policy
+		}
+	}
+}
+
+// hand funcs rejected as the queue is full to `fn` instead of failing them, `name` is empty for
+// unnamed funcs, e.g. to run them in the caller's goroutine or persist them for later
+func WithRejectionHandler(fn func(name string, f func(ctx context.Context) error)) Option {
+	return func(g *Group) {
+		g.onReject = fn
+	}
+}
+
+// report `j` rejected as the queue is full
+func (g *Group) rejectJob(j job) {
+	if j.t.held != nil {
+		j.t.held()
+		j.t.held = nil
+	}
+	if j.step != nil {
+		// a run of `GoEvery`, the func is finished by its ticker loop
+		g.reportRejected(j)
+		j.step <- ErrQueueFull
+		return
+	}
+	defer g.wg.Done()
+	defer g.count(&g.finished)
+	defer g.leaveLane(j.t)
+	g.reportRejected(j)
+	g.done(j.t, ErrQueueFull)
+}
+
+func (g *Group) reportRejected(j job) {
+	switch {
+	case g.onReject != nil:
+		g.onReject(j.t.name, j.t.fn)
+	case j.t.optional:
+		g.skip(j.t, ErrQueueFull)
+	default:
+		g.fail(j.t, ErrQueueFull)
+	}
+}
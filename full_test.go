@@ -0,0 +1,81 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestQueueFullPolicy(t *testing.T) {
+	cases := []struct {
+		policy   errgroup.QueueFullPolicy
+		handle   bool
+		ran      []string
+		rejected string
+	}{
+		{policy: errgroup.RejectNewest, ran: []string{"a", "b"}, rejected: "c"},
+		{policy: errgroup.RejectOldest, ran: []string{"b", "c"}, rejected: "a"},
+		{policy: errgroup.RejectNewest, handle: true, ran: []string{"a", "b"}, rejected: "c"},
+	}
+
+	for _, tc := range cases {
+		var (
+			mu       sync.Mutex
+			ran      []string
+			handled  string
+			opts     = []errgroup.Option{errgroup.WithQueue(2), errgroup.WithQueueFullPolicy(tc.policy)}
+			started  = make(chan struct{})
+			release  = make(chan struct{})
+			recorder = func(name string) func() error {
+				return func() error {
+					mu.Lock()
+					ran = append(ran, name)
+					mu.Unlock()
+					return nil
+				}
+			}
+		)
+		if tc.handle {
+			opts = append(opts, errgroup.WithRejectionHandler(func(name string, _ func(context.Context) error) {
+				handled = name
+			}))
+		}
+		g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0, opts...)
+		g.Go(func() error {
+			close(started)
+			<-release
+			return nil
+		})
+		<-started
+		for _, name := range []string{"a", "b", "c"} {
+			g.GoNamed(name, recorder(name))
+		}
+		close(release)
+
+		errs := g.Wait()
+		sort.Strings(ran)
+		if !reflect.DeepEqual(ran, tc.ran) {
+			t.Errorf("policy %d ran %v; want %v", tc.policy, ran, tc.ran)
+		}
+		if tc.handle {
+			if handled != tc.rejected || len(errs) > 0 {
+				t.Errorf("policy %d handed %q to the handler with %d errors; want %q and no error", tc.policy, handled, len(errs), tc.rejected)
+			}
+			continue
+		}
+		if len(errs) != 1 {
+			t.Fatalf("policy %d: g.Wait() returned %d errors; want 1", tc.policy, len(errs))
+		}
+		if err := <-errs; !errors.Is(err, errgroup.ErrQueueFull) {
+			t.Errorf("policy %d: g.Wait() = %v; want %v", tc.policy, err, errgroup.ErrQueueFull)
+		}
+		if err := <-g.DoneCh(tc.rejected); !errors.Is(err, errgroup.ErrQueueFull) {
+			t.Errorf("policy %d rejected func %q with %v; want %v", tc.policy, tc.rejected, err, errgroup.ErrQueueFull)
+		}
+	}
+}
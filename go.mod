@@ -1,8 +1,8 @@
 module github.com/FelixSeptem/errgroup
 
-go 1.14
+go 1.21
 
 require (
 	github.com/cenkalti/backoff v2.2.1+incompatible
-	golang.org/x/sync v0.0.0-20200317015054-43a5402ce75a
+	golang.org/x/sync v0.3.0
 )
@@ -0,0 +1,13 @@
+package errgroup
+
+// spawn at most `n` goroutines running funcs at a time, `Go` block until a running func returned
+// once reached, unlike `maxConcurrency` which only limit funcs running while waiting funcs still
+// hold a goroutine each, so millions of submissions not blow up memory
+// funcs passing funcs to the same group may dead lock once reached, <= 0 mean no limit
+func WithMaxGoroutines(n int) Option {
+	return func(g *Group) {
+		if n > 0 {
+			g.goroutines = make(chan struct{}, n)
+		}
+	}
+}
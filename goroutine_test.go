@@ -0,0 +1,42 @@
+package errgroup_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestMaxGoroutines(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0, errgroup.WithMaxGoroutines(2))
+	release := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		g.Go(func() error {
+			<-release
+			return nil
+		})
+	}
+
+	before := runtime.NumGoroutine()
+	submitted := make(chan struct{})
+	go func() {
+		g.Go(func() error { return nil })
+		close(submitted)
+	}()
+	select {
+	case <-submitted:
+		t.Fatalf("g.Go() returned with 2 goroutines running funcs; want it blocked")
+	case <-time.After(20 * time.Millisecond):
+	}
+	if n := runtime.NumGoroutine(); n > before+1 {
+		t.Errorf("%d goroutines spawned while g.Go() blocked; want at most 1", n-before)
+	}
+
+	close(release)
+	<-submitted
+	if errs := g.Wait(); len(errs) > 0 {
+		t.Errorf("g.Wait() = %v; want no error", <-errs)
+	}
+}
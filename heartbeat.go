@@ -0,0 +1,107 @@
+package errgroup
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// liveness of the running attempt of a func, see `Heartbeat`
+type heartbeat struct {
+	mu      sync.Mutex
+	last    time.Time
+	stalled bool
+	stopped bool
+	timer   *time.Timer
+}
+
+// report the func running with `ctx` is making progress, see `WithStallDetection`
+// false if `ctx` is not passed by the group, see `GoContext`
+func Heartbeat(ctx context.Context) bool {
+	t, ok := ctx.Value(taskKey{}).(*task)
+	if !ok {
+		return false
+	}
+	hb := t.heartbeat
+	hb.mu.Lock()
+	defer hb.mu.Unlock()
+	hb.last = time.Now()
+	if hb.stalled {
+		hb.stalled = false
+		t.group.countStalled(-1)
+	}
+	return true
+}
+
+// flag a running attempt as stalled once it not called `Heartbeat` for `threshold`, the time
+// since it started counts as the first heartbeat, `onStall` is called with the name of the
+// func and how long it was idle if not nil, see `Stalled`, an attempt calling `Heartbeat`
+// again is no longer stalled
+func WithStallDetection(threshold time.Duration, onStall func(name string, idle time.Duration)) Option {
+	return func(g *Group) {
+		g.stallAfter = threshold
+		g.onStall = onStall
+	}
+}
+
+// number of running attempts stalled now, see `WithStallDetection`
+func (g *Group) Stalled() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.stalled
+}
+
+func (g *Group) countStalled(n int64) {
+	g.mu.Lock()
+	g.stalled += n
+	g.mu.Unlock()
+}
+
+// start watching heartbeats of an attempt of `t`, return func to stop once it returned
+func (g *Group) watch(t *task) func() {
+	hb := t.heartbeat
+	hb.mu.Lock()
+	defer hb.mu.Unlock()
+	hb.last, hb.stalled, hb.stopped = time.Now(), false, false
+	if g.stallAfter <= 0 {
+		return func() {}
+	}
+	hb.timer = time.AfterFunc(g.stallAfter, func() { g.checkStall(t) })
+	return func() {
+		hb.mu.Lock()
+		defer hb.mu.Unlock()
+		hb.timer.Stop()
+		hb.stopped = true
+		if hb.stalled {
+			hb.stalled = false
+			g.countStalled(-1)
+		}
+	}
+}
+
+// flag `t` as stalled if idle for too long, check again once it could be
+func (g *Group) checkStall(t *task) {
+	hb := t.heartbeat
+	hb.mu.Lock()
+	if hb.stopped {
+		hb.mu.Unlock()
+		return
+	}
+	idle := time.Since(hb.last)
+	if idle < g.stallAfter {
+		hb.timer.Reset(g.stallAfter - idle)
+		hb.mu.Unlock()
+		return
+	}
+	hb.timer.Reset(g.stallAfter)
+	if hb.stalled {
+		hb.mu.Unlock()
+		return
+	}
+	hb.stalled = true
+	hb.mu.Unlock()
+	g.countStalled(1)
+	if g.onStall != nil {
+		g.onStall(t.name, idle)
+	}
+}
@@ -0,0 +1,51 @@
+package errgroup_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestStallDetection(t *testing.T) {
+	stalls := make(chan string, 1)
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0,
+		errgroup.WithStallDetection(20*time.Millisecond, func(name string, idle time.Duration) {
+			if idle < 20*time.Millisecond {
+				t.Errorf("stalled after %v; want >= 20ms", idle)
+			}
+			stalls <- name
+		}))
+	if errgroup.Heartbeat(context.Background()) {
+		t.Errorf("Heartbeat(context.Background()) = true; want false")
+	}
+
+	release := make(chan struct{})
+	g.GoNamed("quick", func() error {
+		return nil
+	})
+	g.GoContext(func(ctx context.Context) error {
+		<-release
+		errgroup.Heartbeat(ctx)
+		return nil
+	})
+	g.GoContext(func(ctx context.Context) error {
+		for i := 0; i < 5; i++ {
+			time.Sleep(10 * time.Millisecond)
+			errgroup.Heartbeat(ctx)
+		}
+		return nil
+	})
+	if name := <-stalls; name != "" {
+		t.Errorf("stalled func %q; want the unnamed hung one", name)
+	}
+	if n := g.Stalled(); n != 1 {
+		t.Errorf("g.Stalled() = %d; want 1", n)
+	}
+	close(release)
+	g.Wait()
+	if n := g.Stalled(); n != 0 {
+		t.Errorf("g.Stalled() after funcs returned = %d; want 0", n)
+	}
+}
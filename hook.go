@@ -0,0 +1,96 @@
+package errgroup
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// TaskInfo describe a func about to start, see `Hook`
+type TaskInfo struct {
+	// empty for unnamed funcs
+	Name string
+	// see `GoTagged`, must not be modified
+	Tags map[string]string
+	// when the func was passed, or the run became due for `GoEvery`, time until it started
+	// includes the queue of `WithQueue`, the delay of `GoAfter` and waits for deps and lanes
+	Queued time.Time
+	// time the func waited for its slots
+	Wait time.Duration
+	// ctx the func was passed from, nil unless passed by `GoFrom`
+	Parent context.Context
+}
+
+// Hook observe funcs of a group through their ctx, e.g. to trace them, see `WithHook`
+type Hook interface {
+	// called once a func got its slots, return ctx its attempts derive from and func called
+	// once it returned with the number of attempts made and its final error
+	StartTask(ctx context.Context, info TaskInfo) (context.Context, func(attempts int, err error))
+	// called before every attempt, `attempt` count from 1, return ctx passed to the func and
+	// func called with the error of the attempt
+	StartAttempt(ctx context.Context, attempt int) (context.Context, func(err error))
+}
+
+// running unit func passed from `ctx`, e.g. ctx of a request handler, `f` still get ctx of the
+// group but hooks see `ctx` as `TaskInfo.Parent`, so a func starting long after it was passed,
+// e.g. due to `WithQueue`, is traced as child of its submitter rather than of the group
+func (g *Group) GoFrom(ctx context.Context, f func(ctx context.Context) error) *Task {
+	t := g.newTask("", f)
+	t.parent = ctx
+	return g.submit(t)
+}
+
+// call `h` around every func and attempt of the group, ctx returned by a hook is passed to the
+// next one, hooks are called in the order they were passed and ended in reverse order
+func WithHook(h Hook) Option {
+	return func(g *Group) {
+		g.hooks = append(g.hooks, h)
+	}
+}
+
+// start hooks of `t`, return func to end them
+func (g *Group) startTask(t *task, wait time.Duration) func(attempts int, err error) {
+	t.runCtx = t.ctx
+	atomic.StoreInt64(&t.attempts, 0)
+	if g.hooks == nil {
+		return func(int, error) {}
+	}
+	info := TaskInfo{Name: t.name, Tags: t.tags, Queued: t.due, Wait: wait, Parent: t.parent}
+	ends := make([]func(int, error), len(g.hooks))
+	for i, h := range g.hooks {
+		t.runCtx, ends[i] = h.StartTask(t.runCtx, info)
+	}
+	return func(attempts int, err error) {
+		for i := len(ends) - 1; i >= 0; i-- {
+			ends[i](attempts, err)
+		}
+	}
+}
+
+// count attempts `f` of `t` in the current run, attempts rejected by `WithCircuitBreaker` included
+func countAttempts(t *task, f func() error) func() error {
+	return func() error {
+		atomic.AddInt64(&t.attempts, 1)
+		return f()
+	}
+}
+
+// wrap attempts `f` of `t` with hooks, panics included
+func (g *Group) hook(t *task, f func() error) func() error {
+	return func() error {
+		attempt := int(atomic.LoadInt64(&t.attempts))
+		t.attemptCtx = t.runCtx
+		if g.hooks == nil {
+			return f()
+		}
+		ends := make([]func(error), len(g.hooks))
+		for i, h := range g.hooks {
+			t.attemptCtx, ends[i] = h.StartAttempt(t.attemptCtx, attempt)
+		}
+		err := f()
+		for i := len(ends) - 1; i >= 0; i-- {
+			ends[i](err)
+		}
+		return err
+	}
+}
@@ -0,0 +1,124 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+type spanKey struct{}
+
+// record hook calls, nest names of spans in ctx
+type recordingHook struct {
+	prefix string
+	mu     sync.Mutex
+	calls  []string
+}
+
+func (h *recordingHook) record(s string) {
+	h.mu.Lock()
+	h.calls = append(h.calls, s)
+	h.mu.Unlock()
+}
+
+func (h *recordingHook) StartTask(ctx context.Context, info errgroup.TaskInfo) (context.Context, func(int, error)) {
+	h.record(h.prefix + "start " + info.Name)
+	return context.WithValue(ctx, spanKey{}, h.prefix+info.Name), func(attempts int, err error) {
+		h.record(fmt.Sprintf("%send %s after %d attempts: %v", h.prefix, info.Name, attempts, err))
+	}
+}
+
+func (h *recordingHook) StartAttempt(ctx context.Context, attempt int) (context.Context, func(error)) {
+	parent, _ := ctx.Value(spanKey{}).(string)
+	h.record(fmt.Sprintf("%sattempt %d of %s", h.prefix, attempt, parent))
+	return ctx, func(err error) {
+		h.record(fmt.Sprintf("%sattempt %d: %v", h.prefix, attempt, err))
+	}
+}
+
+func TestWithHook(t *testing.T) {
+	outer, inner := &recordingHook{}, &recordingHook{prefix: "inner "}
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true,
+		&errgroup.RetryOption{Mode: errgroup.Constant, Interval: time.Millisecond, MaxRetries: 1}, 0,
+		errgroup.WithHook(outer), errgroup.WithHook(inner))
+	errFlaky := errors.New("hook_test: flaky")
+	fails := 1
+	g.GoNamed("flaky", func() error {
+		if fails > 0 {
+			fails--
+			return errFlaky
+		}
+		return nil
+	})
+	g.Wait()
+	want := []string{
+		"start flaky",
+		"attempt 1 of inner flaky",
+		"attempt 1: hook_test: flaky",
+		"attempt 2 of inner flaky",
+		"attempt 2: <nil>",
+		"end flaky after 2 attempts: <nil>",
+	}
+	if !reflect.DeepEqual(outer.calls, want) {
+		t.Errorf("outer hook calls = %q; want %q", outer.calls, want)
+	}
+	if len(inner.calls) != len(want) || inner.calls[1] != "inner attempt 1 of inner flaky" {
+		t.Errorf("inner hook calls = %q", inner.calls)
+	}
+}
+
+func TestWithHookPanic(t *testing.T) {
+	h := &recordingHook{}
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0, errgroup.WithHook(h))
+	g.GoContext(func(ctx context.Context) error {
+		if name, _ := ctx.Value(spanKey{}).(string); name != "" {
+			t.Errorf("ctx of unnamed func carry span %q", name)
+		}
+		panic("hook_test: panic")
+	})
+	g.Wait()
+	want := []string{
+		"start ",
+		"attempt 1 of ",
+		"attempt 1: panic: hook_test: panic",
+		"end  after 1 attempts: panic: hook_test: panic",
+	}
+	if !reflect.DeepEqual(h.calls, want) {
+		t.Errorf("hook calls = %q; want %q", h.calls, want)
+	}
+}
+
+type parentHook struct {
+	parent chan context.Context
+}
+
+func (h parentHook) StartTask(ctx context.Context, info errgroup.TaskInfo) (context.Context, func(int, error)) {
+	h.parent <- info.Parent
+	return ctx, func(int, error) {}
+}
+
+func (h parentHook) StartAttempt(ctx context.Context, _ int) (context.Context, func(error)) {
+	return ctx, func(error) {}
+}
+
+func TestGoFrom(t *testing.T) {
+	h := parentHook{parent: make(chan context.Context, 2)}
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0, errgroup.WithHook(h))
+	submitter := context.WithValue(context.Background(), spanKey{}, "handler")
+	g.GoFrom(submitter, func(context.Context) error { return nil })
+	g.Wait()
+	if parent := <-h.parent; parent != submitter {
+		t.Errorf("TaskInfo.Parent = %v; want ctx passed to GoFrom", parent)
+	}
+	g.Go(func() error { return nil })
+	g.Wait()
+	if parent := <-h.parent; parent != nil {
+		t.Errorf("TaskInfo.Parent of func passed by Go = %v; want nil", parent)
+	}
+}
@@ -0,0 +1,26 @@
+package errgroup
+
+import (
+	"context"
+	"runtime/pprof"
+	"strconv"
+	"sync/atomic"
+)
+
+// pprof label set to `Group.ID` on goroutines running funcs of the group
+const PprofLabel = "errgroup.group"
+
+// last id given to a group
+var lastID uint64
+
+// unique id of the group, set as pprof label `PprofLabel` on goroutines running its funcs and
+// goroutines they start, so profiles and leak checks can tell them apart, see `errgrouptest`
+func (g *Group) ID() string {
+	return g.id
+}
+
+// label ctx of a new group with its id
+func withID(ctx context.Context) (context.Context, string) {
+	id := strconv.FormatUint(atomic.AddUint64(&lastID, 1), 10)
+	return pprof.WithLabels(ctx, pprof.Labels(PprofLabel, id)), id
+}
@@ -0,0 +1,24 @@
+package errgroup_test
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestID(t *testing.T) {
+	g1, _ := errgroup.NewGroup(context.Background())
+	g2, _ := errgroup.NewGroup(context.Background())
+	if g1.ID() == "" || g1.ID() == g2.ID() {
+		t.Fatalf("expected unique ids, got %q and %q", g1.ID(), g2.ID())
+	}
+	g1.GoContext(func(ctx context.Context) error {
+		if id, _ := pprof.Label(ctx, errgroup.PprofLabel); id != g1.ID() {
+			t.Errorf("expected label %q, got %q", g1.ID(), id)
+		}
+		return nil
+	})
+	g1.Wait()
+}
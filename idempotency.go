@@ -0,0 +1,34 @@
+package errgroup
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type taskKey struct{}
+
+// random key of a func, generated once when it's passed to the group
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// idempotency key of the func running with `ctx`, the same for every retry of the func and
+// unique across funcs, pass it to payment-like APIs so retries of the group are safe
+// only funcs taking ctx get it, e.g. `GoContext`, false if `ctx` is not passed by the group
+func IdempotencyKey(ctx context.Context) (string, bool) {
+	t, ok := ctx.Value(taskKey{}).(*task)
+	if !ok {
+		return "", false
+	}
+	return t.key, true
+}
+
+// running unit func with the group's ctx carrying settings of the func, see `IdempotencyKey`
+func (g *Group) GoContext(f func(ctx context.Context) error) *Task {
+	return g.submit(g.newTask("", f))
+}
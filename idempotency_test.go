@@ -0,0 +1,61 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	errDeclined := errors.New("idempotency_test: declined")
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, &errgroup.RetryOption{
+		Mode:       errgroup.Constant,
+		Interval:   time.Millisecond,
+		MaxRetries: 2,
+	}, 0)
+
+	var (
+		mu   sync.Mutex
+		keys = map[int]map[string]int{}
+	)
+	for i := 0; i < 3; i++ {
+		i := i
+		g.GoContext(func(ctx context.Context) error {
+			key, ok := errgroup.IdempotencyKey(ctx)
+			if !ok {
+				t.Errorf("func %d has no idempotency key", i)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if keys[i] == nil {
+				keys[i] = map[string]int{}
+			}
+			keys[i][key]++
+			return errDeclined
+		})
+	}
+	g.Wait()
+
+	seen := map[string]bool{}
+	for i, ks := range keys {
+		if len(ks) != 1 {
+			t.Errorf("func %d got %d keys across retries; want 1", i, len(ks))
+		}
+		for key, n := range ks {
+			if n != 3 {
+				t.Errorf("func %d called %d times with key %q; want 3", i, n, key)
+			}
+			if seen[key] {
+				t.Errorf("key %q shared by several funcs", key)
+			}
+			seen[key] = true
+		}
+	}
+	if _, ok := errgroup.IdempotencyKey(context.Background()); ok {
+		t.Errorf("IdempotencyKey(context.Background()) reported a key")
+	}
+}
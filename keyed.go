@@ -0,0 +1,72 @@
+package errgroup
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// allow at most `n` funcs of the same key to run at a time in addition to `maxConcurrency`,
+// e.g. requests per downstream host, so one slow backend can't take all slots, see `GoKeyed`
+func WithKeyLimit(n int64) Option {
+	return func(g *Group) {
+		if n > 0 {
+			g.keys = &keyLimiter{limit: n, slots: make(map[string]*keySlots)}
+		}
+	}
+}
+
+// running unit func of `key` limited by `WithKeyLimit`, empty key mean no key
+func (g *Group) GoKeyed(key string, f func() error) *Task {
+	return g.GoKeyedContext(key, withoutCtx(f))
+}
+
+// running unit func of `key` like `GoKeyed` with the group's ctx carrying settings of the func
+func (g *Group) GoKeyedContext(key string, f func(ctx context.Context) error) *Task {
+	t := g.newTask("", f)
+	t.slotKey = key
+	return g.submit(t)
+}
+
+// slots of a key and how many funcs hold or wait for them
+type keySlots struct {
+	sema *semaphore.Weighted
+	refs int
+}
+
+// per-key semaphores, removed once no func of the key holds or waits for a slot
+type keyLimiter struct {
+	mu    sync.Mutex
+	limit int64
+	slots map[string]*keySlots
+}
+
+// wait for a slot of `key`, return func to release it
+func (l *keyLimiter) acquire(ctx context.Context, key string) (func(), error) {
+	l.mu.Lock()
+	s, ok := l.slots[key]
+	if !ok {
+		s = &keySlots{sema: semaphore.NewWeighted(l.limit)}
+		l.slots[key] = s
+	}
+	s.refs++
+	l.mu.Unlock()
+
+	if err := s.sema.Acquire(ctx, 1); err != nil {
+		l.unref(key, s)
+		return nil, err
+	}
+	return func() {
+		s.sema.Release(1)
+		l.unref(key, s)
+	}, nil
+}
+
+func (l *keyLimiter) unref(key string, s *keySlots) {
+	l.mu.Lock()
+	if s.refs--; s.refs == 0 {
+		delete(l.slots, key)
+	}
+	l.mu.Unlock()
+}
@@ -0,0 +1,61 @@
+package errgroup_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestGoKeyed(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 4, true, nil, 0, errgroup.WithKeyLimit(2))
+	var (
+		mu      sync.Mutex
+		running = map[string]int{}
+		peak    = map[string]int{}
+	)
+	track := func(host string) func() error {
+		return func() error {
+			mu.Lock()
+			running[host]++
+			if running[host] > peak[host] {
+				peak[host] = running[host]
+			}
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			running[host]--
+			mu.Unlock()
+			return nil
+		}
+	}
+	for i := 0; i < 10; i++ {
+		g.GoKeyed("slow.example.com", track("slow.example.com"))
+		g.GoKeyed("fast.example.com", track("fast.example.com"))
+	}
+	if errs := g.Wait(); len(errs) > 0 {
+		t.Errorf("g.Wait() = %v; want no error", <-errs)
+	}
+	for host, n := range peak {
+		if n > 2 {
+			t.Errorf("%d funcs of %q ran at a time; want at most 2", n, host)
+		}
+	}
+}
+
+func TestGoKeyedContext(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0, errgroup.WithKeyLimit(1))
+	task := g.GoKeyedContext("host-a", func(ctx context.Context) error {
+		if _, ok := errgroup.IdempotencyKey(ctx); !ok {
+			t.Errorf("ctx of GoKeyedContext not passed by the group")
+		}
+		return context.DeadlineExceeded
+	})
+	<-task.Done()
+	if task.Err() != context.DeadlineExceeded {
+		t.Errorf("task.Err() = %v; want %v", task.Err(), context.DeadlineExceeded)
+	}
+	g.Wait()
+}
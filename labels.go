@@ -0,0 +1,30 @@
+package errgroup
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// pprof hook, see `WithPprofLabels`
+type labelHook struct {
+	group string
+}
+
+// label goroutines running funcs with "errgroup" `group` and "task" name of the func, so CPU
+// and goroutine profiles attribute time to groups and funcs instead of anonymous closures,
+// ctx passed to funcs carry the labels as well, see `pprof.Do`
+func WithPprofLabels(group string) Option {
+	return WithHook(labelHook{group: group})
+}
+
+func (h labelHook) StartTask(ctx context.Context, info TaskInfo) (context.Context, func(int, error)) {
+	labeled := pprof.WithLabels(ctx, pprof.Labels("errgroup", h.group, "task", info.Name))
+	pprof.SetGoroutineLabels(labeled)
+	return labeled, func(int, error) {
+		pprof.SetGoroutineLabels(ctx)
+	}
+}
+
+func (labelHook) StartAttempt(ctx context.Context, attempt int) (context.Context, func(error)) {
+	return ctx, func(error) {}
+}
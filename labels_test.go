@@ -0,0 +1,24 @@
+package errgroup_test
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestWithPprofLabels(t *testing.T) {
+	errgroup.RegisterArchetype("labels_test", errgroup.ArchetypeConfig{})
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0, errgroup.WithPprofLabels("crawler"))
+	var group, task string
+	g.GoAs("labels_test", func(ctx context.Context) error {
+		group, _ = pprof.Label(ctx, "errgroup")
+		task, _ = pprof.Label(ctx, "task")
+		return nil
+	})
+	g.Wait()
+	if group != "crawler" || task != "labels_test" {
+		t.Errorf("labels = %q, %q; want %q, %q", group, task, "crawler", "labels_test")
+	}
+}
@@ -0,0 +1,38 @@
+package errgroup
+
+// run funcs of the same key of `GoKeyed` one by one in the order they were passed, funcs of
+// different keys run in parallel, like events of one entity which must be processed in order
+// a func runs after the previous one of its key returned, failed or not, and hold no slot
+// meanwhile, it's kept out of the queue of `WithQueue` until then, whatever `DispatchOrder`
+func WithLanes() Option {
+	return func(g *Group) {
+		g.lanes = make(map[string]*task)
+	}
+}
+
+// put `t` at the end of its lane, must be called in the order funcs are passed
+func (g *Group) joinLane(t *task) {
+	if g.lanes == nil || t.slotKey == "" {
+		return
+	}
+	t.laneWait, t.laneDone = nil, make(chan struct{})
+	g.mu.Lock()
+	if prev, ok := g.lanes[t.slotKey]; ok {
+		t.laneWait = prev.laneDone
+	}
+	g.lanes[t.slotKey] = t
+	g.mu.Unlock()
+}
+
+// let the next func of the lane of `t` run
+func (g *Group) leaveLane(t *task) {
+	if t.laneDone == nil {
+		return
+	}
+	g.mu.Lock()
+	if g.lanes[t.slotKey] == t {
+		delete(g.lanes, t.slotKey)
+	}
+	g.mu.Unlock()
+	close(t.laneDone)
+}
@@ -0,0 +1,83 @@
+package errgroup_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestLanes(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 4, true, nil, 0, errgroup.WithLanes())
+	var (
+		mu      sync.Mutex
+		events  = map[string][]int{}
+		running = map[string]int{}
+	)
+	for i := 0; i < 20; i++ {
+		i := i
+		entity := fmt.Sprintf("order-%d", i%3)
+		g.GoKeyed(entity, func() error {
+			mu.Lock()
+			running[entity]++
+			if running[entity] > 1 {
+				t.Errorf("events of %q processed in parallel", entity)
+			}
+			events[entity] = append(events[entity], i)
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			running[entity]--
+			mu.Unlock()
+			return nil
+		})
+	}
+	g.Wait()
+
+	for e := 0; e < 3; e++ {
+		var want []int
+		for i := e; i < 20; i += 3 {
+			want = append(want, i)
+		}
+		entity := fmt.Sprintf("order-%d", e)
+		if !reflect.DeepEqual(events[entity], want) {
+			t.Errorf("events of %q processed in order %v; want %v", entity, events[entity], want)
+		}
+	}
+}
+
+func TestLanesLIFO(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0,
+		errgroup.WithLanes(), errgroup.WithQueue(10), errgroup.WithDispatchOrder(errgroup.LIFO))
+	var order []int
+	block := make(chan struct{})
+	g.Go(func() error {
+		<-block
+		return nil
+	})
+	for i := 0; i < 3; i++ {
+		i := i
+		g.GoKeyed("order-1", func() error {
+			order = append(order, i)
+			return nil
+		})
+	}
+	close(block)
+	done := make(chan struct{})
+	go func() {
+		g.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("g.Wait() hang with lanes dispatched in LIFO order")
+	}
+	if !reflect.DeepEqual(order, []int{0, 1, 2}) {
+		t.Errorf("lane ran in order %v; want [0 1 2]", order)
+	}
+}
@@ -0,0 +1,44 @@
+package errgroup
+
+import "context"
+
+// LateSubmissionPolicy define what happens to funcs passed once ctx of the group is cancelled
+type LateSubmissionPolicy uint8
+
+const (
+	// compete for a slot like funcs passed before, called if one is free at once, otherwise fail
+	// with `ErrAcquireCancelled`, optional funcs are skipped
+	Compete LateSubmissionPolicy = iota
+	// never call the func, it fail with `ErrGroupClosed`
+	Reject
+	// call the func at once with the cancelled ctx ignoring `maxConcurrency`, e.g. for cleanup
+	RunAnyway
+	// wait for a slot ignoring cancellation, then call the func with the cancelled ctx
+	Queue
+)
+
+// choose what happens to funcs passed once ctx of the group is cancelled, by a failed func,
+// by `Wait` or by the parent ctx, default `Compete`
+func WithLateSubmissionPolicy(policy LateSubmissionPolicy) Option {
+	return func(g *Group) {
+		g.late = policy
+	}
+}
+
+// report `t` never called due to `Reject`
+func (g *Group) reject(t *task) error {
+	if t.optional {
+		g.skip(t, ErrGroupClosed)
+	} else {
+		g.fail(t, ErrGroupClosed)
+	}
+	return ErrGroupClosed
+}
+
+// ctx to wait for slots of `t` with, late funcs ignore cancellation due to the policy
+func (g *Group) slotCtx(t *task) context.Context {
+	if t.late && g.late == Queue {
+		return context.WithoutCancel(g.ctx)
+	}
+	return t.ctx
+}
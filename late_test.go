@@ -0,0 +1,87 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestLateSubmissionPolicy(t *testing.T) {
+	cases := []struct {
+		policy errgroup.LateSubmissionPolicy
+		called bool
+		want   error
+	}{
+		{policy: errgroup.Compete, called: false, want: errgroup.ErrAcquireCancelled},
+		{policy: errgroup.Reject, called: false, want: errgroup.ErrGroupClosed},
+		{policy: errgroup.RunAnyway, called: true},
+		{policy: errgroup.Queue, called: true},
+	}
+
+	for _, tc := range cases {
+		parent, cancel := context.WithCancel(context.Background())
+		g, _ := errgroup.NewGroupWithContext(parent, 1, true, nil, 0, errgroup.WithLateSubmissionPolicy(tc.policy))
+		started, release := make(chan struct{}), make(chan struct{})
+		g.Go(func() error {
+			close(started)
+			<-release
+			return nil
+		})
+		<-started
+		cancel()
+
+		var (
+			called  int32
+			running = make(chan struct{}, 1)
+		)
+		g.Go(func() error {
+			atomic.StoreInt32(&called, 1)
+			running <- struct{}{}
+			return nil
+		})
+		switch tc.policy {
+		case errgroup.RunAnyway:
+			// called while the only slot is still taken
+			<-running
+		case errgroup.Compete, errgroup.Reject:
+			for g.Summary().Failed == 0 {
+				time.Sleep(time.Millisecond)
+			}
+		}
+		close(release)
+
+		errs := g.Wait()
+		if got := atomic.LoadInt32(&called) == 1; got != tc.called {
+			t.Errorf("policy %d called late func = %v; want %v", tc.policy, got, tc.called)
+		}
+		if tc.want == nil && len(errs) > 0 {
+			t.Errorf("policy %d: g.Wait() = %v; want no error", tc.policy, <-errs)
+		}
+		if tc.want != nil && (len(errs) != 1 || !errors.Is(<-errs, tc.want)) {
+			t.Errorf("policy %d: g.Wait() did not report %v", tc.policy, tc.want)
+		}
+	}
+}
+
+func TestGoAfterWaitReturned(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0,
+		errgroup.WithLateSubmissionPolicy(errgroup.Reject))
+	g.Go(func() error { return nil })
+	g.Wait()
+	called := false
+	task := g.Go(func() error {
+		called = true
+		return nil
+	})
+	<-task.Done()
+	if !errors.Is(task.Err(), errgroup.ErrGroupClosed) || called {
+		t.Errorf("task.Err() = %v, called %v; want ErrGroupClosed and not called", task.Err(), called)
+	}
+	if err := <-g.Wait(); !errors.Is(err, errgroup.ErrGroupClosed) {
+		t.Errorf("next g.Wait() = %v; want ErrGroupClosed", err)
+	}
+}
@@ -0,0 +1,82 @@
+package errgroup
+
+import (
+	"context"
+	"math"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// files holding CPU quota of the container, cgroup v2 first
+var cpuQuotaFiles = [][2]string{
+	{"/sys/fs/cgroup/cpu.max", ""},
+	{"/sys/fs/cgroup/cpu/cpu.cfs_quota_us", "/sys/fs/cgroup/cpu/cpu.cfs_period_us"},
+}
+
+// concurrency fitting CPUs the process may use, GOMAXPROCS or CPU quota of the container if
+// lower, never less than 1, for CPU bound funcs instead of a magic `maxConcurrency`
+func DefaultLimit() int64 {
+	limit := int64(runtime.GOMAXPROCS(0))
+	if quota, ok := cpuQuota(); ok && quota < limit {
+		limit = quota
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
+
+// get a new error group like `NewGroupWithContext` in fail-fast mode with concurrency of
+// `DefaultLimit`, no retry and all errors kept
+func NewGroup(ctx context.Context, opts ...Option) (*Group, context.Context) {
+	return NewGroupWithContext(ctx, DefaultLimit(), false, nil, 0, opts...)
+}
+
+// CPUs due to cgroup quota rounded up, false if no quota
+func cpuQuota() (int64, bool) {
+	for _, files := range cpuQuotaFiles {
+		quota, period, ok := readQuota(files[0], files[1])
+		if !ok {
+			continue
+		}
+		if quota <= 0 || period <= 0 {
+			return 0, false
+		}
+		return int64(math.Ceil(quota / period)), true
+	}
+	return 0, false
+}
+
+// read quota and period from `quotaFile`, "max 100000" for cgroup v2, or from both files for
+// cgroup v1, quota <= 0 mean no quota
+func readQuota(quotaFile, periodFile string) (quota, period float64, ok bool) {
+	b, err := os.ReadFile(quotaFile)
+	if err != nil {
+		return 0, 0, false
+	}
+	fields := strings.Fields(string(b))
+	if periodFile != "" {
+		p, err := os.ReadFile(periodFile)
+		if err != nil {
+			return 0, 0, false
+		}
+		fields = append(fields, strings.Fields(string(p))...)
+	}
+	if len(fields) != 2 {
+		return 0, 0, false
+	}
+	if fields[0] == "max" {
+		return 0, 0, true
+	}
+	quota, err = strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	period, err = strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return quota, period, true
+}
@@ -0,0 +1,32 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestDefaultLimit(t *testing.T) {
+	if limit := errgroup.DefaultLimit(); limit < 1 || limit > int64(runtime.GOMAXPROCS(0)) {
+		t.Errorf("DefaultLimit() = %d; want in [1, %d]", limit, runtime.GOMAXPROCS(0))
+	}
+}
+
+func TestNewGroup(t *testing.T) {
+	g, ctx := errgroup.NewGroup(context.Background())
+	if got := g.Concurrency(); got != errgroup.DefaultLimit() {
+		t.Errorf("g.Concurrency() = %d; want %d", got, errgroup.DefaultLimit())
+	}
+	errFailed := errors.New("failed")
+	g.Go(func() error { return errFailed })
+	errs := g.Wait()
+	if err := <-errs; !errors.Is(err, errFailed) {
+		t.Errorf("g.Wait() = %v; want %v", err, errFailed)
+	}
+	if ctx.Err() == nil {
+		t.Errorf("ctx not cancelled")
+	}
+}
@@ -0,0 +1,21 @@
+package errgroup
+
+import "context"
+
+// Limiter limit how many slots funcs hold at a time, `*semaphore.Weighted` of
+// golang.org/x/sync/semaphore satisfy it, see `WithLimiter`
+type Limiter interface {
+	// block until `n` slots are taken or return error, e.g. once ctx is cancelled
+	Acquire(ctx context.Context, n int64) error
+	// give back `n` slots taken by `Acquire`
+	Release(n int64)
+}
+
+// take slots of funcs from `l` instead of the semaphore of `maxConcurrency` size, e.g. a
+// priority semaphore or a no-op one, it replace `WithAdaptiveConcurrency` passed before it,
+// weight of a func is still capped at `maxConcurrency` if > 0
+func WithLimiter(l Limiter) Option {
+	return func(g *Group) {
+		g.sema = l
+	}
+}
@@ -0,0 +1,56 @@
+package errgroup_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+// count slots taken without limiting
+type countingLimiter struct {
+	mu       sync.Mutex
+	cur, max int64
+}
+
+func (l *countingLimiter) Acquire(_ context.Context, n int64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cur += n
+	if l.cur > l.max {
+		l.max = l.cur
+	}
+	return nil
+}
+
+func (l *countingLimiter) Release(n int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cur -= n
+}
+
+func TestWithLimiter(t *testing.T) {
+	l := &countingLimiter{}
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0, errgroup.WithLimiter(l))
+	if s := g.String(); !strings.Contains(s, "concurrency by limiter") || !strings.Contains(s, ", limiter") {
+		t.Errorf("g.String() = %q; want custom limiter described", s)
+	}
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(3)
+	for i := 0; i < 3; i++ {
+		g.GoWeighted(2, func() error {
+			wg.Done()
+			<-start
+			return nil
+		})
+	}
+	wg.Wait()
+	close(start)
+	g.Wait()
+	if l.max != 6 || l.cur != 0 {
+		t.Errorf("max %d, cur %d slots; want 6, 0", l.max, l.cur)
+	}
+}
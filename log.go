@@ -0,0 +1,131 @@
+package errgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"strings"
+)
+
+// LogLevels is the level lifecycle events are logged at, see `WithLogger`
+type LogLevels struct {
+	// a func starts or succeeded
+	Start, Finish slog.Level
+	// an attempt failed and will be retried
+	Retry slog.Level
+	// a func finally failed
+	Failure slog.Level
+	// a func panicked
+	Panic slog.Level
+	// ctx of the group is cancelled
+	Cancel slog.Level
+}
+
+// levels used unless `WithLogLevels`
+var defaultLogLevels = LogLevels{
+	Start:   slog.LevelDebug,
+	Finish:  slog.LevelDebug,
+	Retry:   slog.LevelInfo,
+	Failure: slog.LevelWarn,
+	Panic:   slog.LevelError,
+	Cancel:  slog.LevelDebug,
+}
+
+// Logger receive lifecycle events of a group, `keysAndValues` alternate keys and values like
+// logr, zap's SugaredLogger and logrus fields, see `WithLoggerAdapter`
+type Logger interface {
+	Log(level slog.Level, msg string, keysAndValues ...interface{})
+}
+
+// LoggerFunc adapt a func to `Logger`, e.g. for zap
+//
+//	errgroup.LoggerFunc(func(level slog.Level, msg string, kv ...interface{}) {
+//		sugar.Logw(zapcore.Level(level/4), msg, kv...)
+//	})
+type LoggerFunc func(level slog.Level, msg string, keysAndValues ...interface{})
+
+func (f LoggerFunc) Log(level slog.Level, msg string, keysAndValues ...interface{}) {
+	f(level, msg, keysAndValues...)
+}
+
+// adapt `l` to `Logger`
+func SlogAdapter(l *slog.Logger) Logger {
+	return LoggerFunc(func(level slog.Level, msg string, keysAndValues ...interface{}) {
+		l.Log(context.Background(), level, msg, keysAndValues...)
+	})
+}
+
+// adapt `l` of the standard log package to `Logger`, records below `min` are dropped
+func StdAdapter(l *log.Logger, min slog.Level) Logger {
+	return LoggerFunc(func(level slog.Level, msg string, keysAndValues ...interface{}) {
+		if level < min {
+			return
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "%v %s", level, msg)
+		for i := 0; i+1 < len(keysAndValues); i += 2 {
+			fmt.Fprintf(&b, " %v=%q", keysAndValues[i], fmt.Sprint(keysAndValues[i+1]))
+		}
+		l.Print(b.String())
+	})
+}
+
+// log funcs starting and finishing, retries with their delay, panics and cancellation of the
+// group to `l` at the levels of `WithLogLevels`, by default debug for start, finish and
+// cancellation, info for retries, warn for failures and error for panics
+func WithLogger(l *slog.Logger) Option {
+	return WithLoggerAdapter(SlogAdapter(l))
+}
+
+// like `WithLogger` for codebases on other logging libraries, see `Logger`
+func WithLoggerAdapter(l Logger) Option {
+	return func(g *Group) {
+		WithEventHandler(func(e Event) { g.log(l, e) })(g)
+	}
+}
+
+// level lifecycle events are logged at, see `WithLogger`, zero fields mean info
+func WithLogLevels(levels LogLevels) Option {
+	return func(g *Group) {
+		g.logLevels = &levels
+	}
+}
+
+func (g *Group) log(l Logger, e Event) {
+	levels := defaultLogLevels
+	if g.logLevels != nil {
+		levels = *g.logLevels
+	}
+	if len(e.Tags) > 0 {
+		next := l
+		l = LoggerFunc(func(level slog.Level, msg string, keysAndValues ...interface{}) {
+			next.Log(level, msg, append(keysAndValues, "tags", e.Tags)...)
+		})
+	}
+	if e.Worker > 0 {
+		next := l
+		l = LoggerFunc(func(level slog.Level, msg string, keysAndValues ...interface{}) {
+			next.Log(level, msg, append(keysAndValues, "worker", e.Worker)...)
+		})
+	}
+	switch e.Kind {
+	case TaskStarted:
+		l.Log(levels.Start, "errgroup: task started", "task", e.Task)
+	case TaskRetried:
+		l.Log(levels.Retry, "errgroup: task retried", "task", e.Task, "attempt", e.Attempt, "delay", e.Delay, "error", e.Err)
+	case TaskFinished:
+		var pe *PanicError
+		switch {
+		case e.Err == nil:
+			l.Log(levels.Finish, "errgroup: task finished", "task", e.Task, "duration", e.Duration)
+		case errors.As(e.Err, &pe):
+			l.Log(levels.Panic, "errgroup: task panicked", "task", e.Task, "panic", pe.Value, "stack", string(pe.Stack))
+		default:
+			l.Log(levels.Failure, "errgroup: task failed", "task", e.Task, "duration", e.Duration, "error", e.Err)
+		}
+	case GroupCancelled:
+		l.Log(levels.Cancel, "errgroup: group cancelled", "cause", context.Cause(g.ctx))
+	}
+}
@@ -0,0 +1,88 @@
+package errgroup_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true,
+		&errgroup.RetryOption{Mode: errgroup.Constant, Interval: time.Millisecond, MaxRetries: 1}, 0,
+		errgroup.WithLogger(l))
+	g.GoNamed("ok", func() error { return nil })
+	g.GoNamed("flaky", func() error { return errors.New("log_test: failed") })
+	g.GoNamed("crash", func() error { panic("log_test: panic") })
+	g.Wait()
+
+	out := buf.String()
+	for _, want := range []string{
+		`level=INFO msg="errgroup: task retried" task=flaky attempt=1 delay=1ms error="log_test: failed"`,
+		`level=WARN msg="errgroup: task failed" task=flaky`,
+		`level=ERROR msg="errgroup: task panicked" task=crash panic="log_test: panic"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log %q not contain %q", out, want)
+		}
+	}
+	if strings.Contains(out, "task started") || strings.Contains(out, "group cancelled") {
+		t.Errorf("log %q contain debug events", out)
+	}
+}
+
+func TestWithLogLevels(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, false, nil, 0,
+		errgroup.WithLogLevels(errgroup.LogLevels{Start: slog.LevelInfo, Cancel: slog.LevelWarn}),
+		errgroup.WithLogger(l))
+	g.GoNamed("failed", func() error { return errors.New("log_test: failed") })
+	g.Wait()
+
+	out := buf.String()
+	for _, want := range []string{
+		`level=INFO msg="errgroup: task started" task=failed`,
+		`level=WARN msg="errgroup: group cancelled" cause="errgroup: cancelled by failed task \"failed\": log_test: failed"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log %q not contain %q", out, want)
+		}
+	}
+}
+
+func TestWithLoggerAdapter(t *testing.T) {
+	var buf bytes.Buffer
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0,
+		errgroup.WithLoggerAdapter(errgroup.StdAdapter(log.New(&buf, "", 0), slog.LevelWarn)))
+	g.GoNamed("failed", func() error { return errors.New("log_test: failed") })
+	g.GoNamed("ok", func() error { return nil })
+	g.Wait()
+	if want := `WARN errgroup: task failed task="failed" duration=`; !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("log %q; want prefix %q", buf.String(), want)
+	}
+	if strings.Count(buf.String(), "\n") != 1 {
+		t.Errorf("log %q; want only the failure", buf.String())
+	}
+
+	var kvs [][]interface{}
+	g, _ = errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0,
+		errgroup.WithLoggerAdapter(errgroup.LoggerFunc(func(level slog.Level, msg string, kv ...interface{}) {
+			if msg == "errgroup: task started" {
+				kvs = append(kvs, kv)
+			}
+		})))
+	g.GoNamed("ok", func() error { return nil })
+	g.Wait()
+	if len(kvs) != 1 || len(kvs[0]) != 2 || kvs[0][0] != "task" || kvs[0][1] != "ok" {
+		t.Errorf("keys and values = %v; want [[task ok]]", kvs)
+	}
+}
@@ -0,0 +1,57 @@
+package errgroup
+
+import (
+	"context"
+	"runtime/metrics"
+	"sync/atomic"
+	"time"
+)
+
+// interval to check memory usage while funcs are paused, see `WithMemoryAdmission`
+const memoryPollInterval = 10 * time.Millisecond
+
+// pause starting funcs while memory usage exceed `limit` bytes, to prevent OOM when every func
+// buffer much data, `usage` report memory usage, nil mean bytes of live heap objects due to
+// runtime/metrics, a func is always started if none is running, so the group make progress
+func WithMemoryAdmission(limit uint64, usage func() uint64) Option {
+	return func(g *Group) {
+		if usage == nil {
+			usage = heapUsage
+		}
+		g.memory = &memoryAdmission{limit: limit, usage: usage}
+	}
+}
+
+func heapUsage() uint64 {
+	sample := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+type memoryAdmission struct {
+	limit   uint64
+	usage   func() uint64
+	running int64
+}
+
+// wait until a func may start, call `done` once it returned
+func (m *memoryAdmission) admit(ctx context.Context) error {
+	for atomic.LoadInt64(&m.running) > 0 && m.usage() > m.limit {
+		timer := time.NewTimer(memoryPollInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+	atomic.AddInt64(&m.running, 1)
+	return nil
+}
+
+func (m *memoryAdmission) done() {
+	atomic.AddInt64(&m.running, -1)
+}
@@ -0,0 +1,49 @@
+package errgroup_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestMemoryAdmission(t *testing.T) {
+	var usage uint64
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0,
+		errgroup.WithMemoryAdmission(100, func() uint64 { return atomic.LoadUint64(&usage) }))
+
+	started, release := make(chan struct{}), make(chan struct{})
+	g.Go(func() error {
+		// buffer much data
+		atomic.StoreUint64(&usage, 150)
+		close(started)
+		<-release
+		atomic.StoreUint64(&usage, 10)
+		return nil
+	})
+	<-started
+	var called int32
+	g.Go(func() error {
+		atomic.StoreInt32(&called, 1)
+		return nil
+	})
+	time.Sleep(30 * time.Millisecond)
+	if atomic.LoadInt32(&called) != 0 {
+		t.Errorf("func started while memory usage exceeded the limit")
+	}
+	close(release)
+	g.Wait()
+	if called != 1 {
+		t.Errorf("paused func not started once memory usage dropped")
+	}
+}
+
+func TestMemoryAdmissionDefaultUsage(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0, errgroup.WithMemoryAdmission(1<<40, nil))
+	g.Go(func() error { return nil })
+	if errs := g.Wait(); len(errs) > 0 {
+		t.Errorf("g.Wait() = %v; want no error", <-errs)
+	}
+}
@@ -0,0 +1,53 @@
+package errgroup
+
+import (
+	"context"
+	"fmt"
+)
+
+// TaskError wrap errors of a named or tagged func, the same name it's shown by in logs, events,
+// hooks, metrics and `Dump`, see `GoNamed` and `GoTagged`
+type TaskError struct {
+	// empty for unnamed funcs
+	Name string
+	// nil for untagged funcs
+	Tags map[string]string
+	Err  error
+}
+
+func (e *TaskError) Error() string {
+	if len(e.Tags) == 0 {
+		return fmt.Sprintf("task %q: %s", e.Name, e.Err)
+	}
+	if e.Name == "" {
+		return fmt.Sprintf("task [%s]: %s", formatTags(e.Tags), e.Err)
+	}
+	return fmt.Sprintf("task %q [%s]: %s", e.Name, formatTags(e.Tags), e.Err)
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// running unit func named `name` with the group's ctx, see `GoNamed` and `TaskName`
+func (g *Group) GoNamedContext(name string, f func(ctx context.Context) error) *Task {
+	return g.submit(g.newTask(name, f))
+}
+
+// name of the func running with `ctx`, e.g. to log with it, empty for unnamed funcs, false if
+// `ctx` is not passed by the group
+func TaskName(ctx context.Context) (string, bool) {
+	t, ok := ctx.Value(taskKey{}).(*task)
+	if !ok {
+		return "", false
+	}
+	return t.name, true
+}
+
+// wrap `err` of `t` with its name and tags, if any
+func (t *task) wrap(err error) error {
+	if t.name == "" && len(t.tags) == 0 {
+		return err
+	}
+	return &TaskError{Name: t.name, Tags: t.tags, Err: err}
+}
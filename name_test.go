@@ -0,0 +1,42 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestGoNamedContext(t *testing.T) {
+	if _, ok := errgroup.TaskName(context.Background()); ok {
+		t.Errorf("TaskName() of a ctx not passed by the group = true; want false")
+	}
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	errFetch := errors.New("name_test: fetch")
+	var names []string
+	<-g.GoNamedContext("fetch", func(ctx context.Context) error {
+		name, ok := errgroup.TaskName(ctx)
+		if !ok {
+			t.Errorf("TaskName() = false; want true")
+		}
+		names = append(names, name)
+		return errFetch
+	}).Done()
+	<-g.GoContext(func(ctx context.Context) error {
+		name, _ := errgroup.TaskName(ctx)
+		names = append(names, name)
+		return nil
+	}).Done()
+	err := <-g.Wait()
+	if len(names) != 2 || names[0] != "fetch" || names[1] != "" {
+		t.Errorf("TaskName() = %q; want fetch and empty", names)
+	}
+	var te *errgroup.TaskError
+	if !errors.As(err, &te) || te.Name != "fetch" || !errors.Is(err, errFetch) {
+		t.Fatalf("g.Wait() = %v; want TaskError of fetch", err)
+	}
+	if te.Error() != `task "fetch": name_test: fetch` {
+		t.Errorf("Error() = %q", te.Error())
+	}
+}
@@ -0,0 +1,45 @@
+package errgroup
+
+import "context"
+
+// Degradation is an optional func failed or skipped, see `GoOptional`
+type Degradation struct {
+	// name passed to `GoOptional`
+	Name string
+	Err  error
+	// true mean the func was never called, as the group was cancelled before it started
+	Skipped bool
+}
+
+// running optional unit func named `name`, like a recommendation block of a page
+// its failure is not reported by `Wait` nor cancel the group, but listed by `Degradations`
+// it's skipped if ctx of the group is cancelled before it starts
+func (g *Group) GoOptional(name string, f func(ctx context.Context) error) *Task {
+	t := g.newTask(name, f)
+	t.optional = true
+	return g.submit(t)
+}
+
+// optional funcs failed or skipped so far, so responses can tell which parts are missing
+func (g *Group) Degradations() []Degradation {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]Degradation(nil), g.degradations...)
+}
+
+// an optional func never called
+func (g *Group) skip(t *task, err error) {
+	if t.optional {
+		g.degrade(t, err, true)
+	}
+}
+
+func (g *Group) degrade(t *task, err error, skipped bool) {
+	if g.dropCancelled(t) {
+		return
+	}
+	g.mu.Lock()
+	g.summary.Degraded++
+	g.degradations = append(g.degradations, Degradation{Name: t.name, Err: err, Skipped: skipped})
+	g.mu.Unlock()
+}
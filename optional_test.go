@@ -0,0 +1,61 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestGoOptional(t *testing.T) {
+	errRecommend := errors.New("optional_test: recommendation service down")
+
+	g, ctx := errgroup.NewGroupWithContext(context.Background(), 2, false, nil, 0)
+	g.GoOptional("recommendations", func(context.Context) error { return errRecommend })
+	g.Go(func() error {
+		for g.Summary().Degraded == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		if ctx.Err() != nil {
+			t.Errorf("optional failure cancelled the group")
+		}
+		return nil
+	})
+	if errs := g.Wait(); len(errs) != 0 {
+		t.Errorf("g.Wait() returned %d errors; want 0", len(errs))
+	}
+
+	degradations := g.Degradations()
+	if len(degradations) != 1 {
+		t.Fatalf("got %d degradations; want 1", len(degradations))
+	}
+	if d := degradations[0]; d.Name != "recommendations" || d.Err != errRecommend || d.Skipped {
+		t.Errorf("degradation = %+v; want recommendations failed with %v", d, errRecommend)
+	}
+	if s := g.Summary(); s.Degraded != 1 || s.Failed != 0 || s.Succeeded != 1 {
+		t.Errorf("g.Summary() = %+v; want 1 succeeded and 1 degraded", s)
+	}
+
+	// the group is cancelled by a failed func before the optional one starts
+	g, _ = errgroup.NewGroupWithContext(context.Background(), 1, false, nil, 0)
+	started, release := make(chan struct{}), make(chan struct{})
+	g.Go(func() error {
+		close(started)
+		<-release
+		return errors.New("optional_test: failed")
+	})
+	<-started
+	g.GoOptional("avatar", func(context.Context) error {
+		t.Errorf("optional func called after the group was cancelled")
+		return nil
+	})
+	close(release)
+	if errs := g.Wait(); len(errs) != 1 {
+		t.Errorf("g.Wait() returned %d errors; want 1", len(errs))
+	}
+	if d := g.Degradations(); len(d) != 1 || !d[0].Skipped {
+		t.Errorf("g.Degradations() = %+v; want avatar skipped", d)
+	}
+}
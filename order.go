@@ -0,0 +1,21 @@
+package errgroup
+
+// DispatchOrder define which waiting func runs next once a slot is free
+type DispatchOrder uint8
+
+const (
+	// the oldest one, for fairness and bounded latency
+	FIFO DispatchOrder = iota
+	// the newest one, whose data is likely still hot in cache
+	LIFO
+)
+
+// run waiting funcs in strict `order`, funcs are kept in the queue of `WithQueue` (unbounded
+// unless set) instead of racing for a slot, ignored if `maxConcurrency` <= 0
+func WithDispatchOrder(order DispatchOrder) Option {
+	return func(g *Group) {
+		if q := g.useQueue(); q != nil {
+			q.order = order
+		}
+	}
+}
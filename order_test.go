@@ -0,0 +1,43 @@
+package errgroup_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestDispatchOrder(t *testing.T) {
+	cases := []struct {
+		order errgroup.DispatchOrder
+		want  []int
+	}{
+		{order: errgroup.FIFO, want: []int{0, 1, 2, 3, 4}},
+		{order: errgroup.LIFO, want: []int{4, 3, 2, 1, 0}},
+	}
+
+	for _, tc := range cases {
+		g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0, errgroup.WithDispatchOrder(tc.order))
+		started, release := make(chan struct{}), make(chan struct{})
+		g.Go(func() error {
+			close(started)
+			<-release
+			return nil
+		})
+		<-started
+		var got []int
+		for i := 0; i < 5; i++ {
+			i := i
+			g.Go(func() error {
+				got = append(got, i)
+				return nil
+			})
+		}
+		close(release)
+		g.Wait()
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("order %d ran funcs in %v; want %v", tc.order, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package errgroup
+
+type OverflowPolicy uint8
+
+const (
+	// drop errors occurred after `maxErrs` errors were collected
+	DropNewest OverflowPolicy = iota
+	// drop the oldest collected error to keep the new one
+	DropOldest
+	// keep the first `maxErrs`-1 errors and the latest one, so both the root cause and the
+	// final state are reported, same as `DropNewest` if `maxErrs` is 1
+	KeepFirstAndLast
+)
+
+// choose which errors to drop once more than `maxErrs` errors occurred, default `DropNewest`
+// never drop errors if `maxErrs` <= 0
+func WithErrOverflow(policy OverflowPolicy) Option {
+	return func(g *Group) {
+		g.err.overflow = policy
+	}
+}
+
+// apply `fn` to every error before it's collected, to attach request ids, redact secrets or
+// convert to internal error types at one place, error is dropped if `fn` return nil
+func WithWrapError(fn func(err error) error) Option {
+	return func(g *Group) {
+		g.wrapErr = fn
+	}
+}
+
+// number of errors dropped as more than `maxErrs` errors occurred, non-zero mean the
+// errors returned by `Wait` are truncated
+func (g *Group) DroppedErrors() int {
+	g.err.mu.Lock()
+	defer g.err.mu.Unlock()
+	return g.err.dropped
+}
+
+// append `entry` to a full error buffer due to overflow policy, must hold `e.mu`
+func (e *errCh) overflowPut(entry *errEntry) {
+	e.dropped++
+	switch {
+	case e.overflow == DropOldest:
+		copy(e.errs, e.errs[1:])
+		e.errs[len(e.errs)-1] = entry
+	case e.overflow == KeepFirstAndLast && e.max > 1:
+		e.errs[len(e.errs)-1] = entry
+	}
+}
@@ -0,0 +1,69 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestErrOverflow(t *testing.T) {
+	cases := []struct {
+		policy  errgroup.OverflowPolicy
+		maxErrs int
+		want    []string
+	}{
+		{policy: errgroup.DropNewest, maxErrs: 3, want: []string{"err 0", "err 1", "err 2"}},
+		{policy: errgroup.DropOldest, maxErrs: 3, want: []string{"err 3", "err 4", "err 5"}},
+		{policy: errgroup.KeepFirstAndLast, maxErrs: 3, want: []string{"err 0", "err 1", "err 5"}},
+		{policy: errgroup.KeepFirstAndLast, maxErrs: 1, want: []string{"err 0"}},
+	}
+
+	for _, tc := range cases {
+		g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, tc.maxErrs, errgroup.WithErrOverflow(tc.policy))
+		for i := 0; i < 6; i++ {
+			// wait each func so errors occur in order
+			i := i
+			g.Go(func() error { return fmt.Errorf("err %d", i) })
+			g.Wait()
+		}
+
+		var got []string
+		for err := range drain(g.Wait()) {
+			got = append(got, err.Error())
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("policy %d kept %v; want %v", tc.policy, got, tc.want)
+		}
+		if dropped := g.DroppedErrors(); dropped != 6-tc.maxErrs {
+			t.Errorf("policy %d dropped %d errors; want %d", tc.policy, dropped, 6-tc.maxErrs)
+		}
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	errSecret := errors.New("overflow_test: password=hunter2")
+	errIgnored := errors.New("overflow_test: ignored")
+
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0,
+		errgroup.WithWrapError(func(err error) error {
+			if errors.Is(err, errIgnored) {
+				return nil
+			}
+			return fmt.Errorf("request 42: %w", err)
+		}))
+	g.Go(func() error { return errSecret })
+	g.Go(func() error { return errIgnored })
+
+	errs := g.Wait()
+	if len(errs) != 1 {
+		t.Fatalf("g.Wait() returned %d errors; want 1", len(errs))
+	}
+	if err := <-errs; !errors.Is(err, errSecret) || !strings.HasPrefix(err.Error(), "request 42: ") {
+		t.Errorf("g.Wait() = %v; want %v wrapped with the request id", err, errSecret)
+	}
+}
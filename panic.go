@@ -0,0 +1,71 @@
+package errgroup
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is reported instead of crashing the process when a func panics
+type PanicError struct {
+	// value passed to panic
+	Value interface{}
+	// stack of the goroutine where the panic occurred
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// return the panic value if it's an error
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// re-raise the first recovered panic from `Wait` on the caller's goroutine instead of
+// reporting it as an error, so panics stay loud during development
+// the value passed to panic is the `*PanicError` carrying the original value and stack
+func WithRepanic() Option {
+	return func(g *Group) {
+		g.repanic = true
+	}
+}
+
+// keep the first panic if `WithRepanic` is used
+func (g *Group) catchPanic(err error) {
+	var pe *PanicError
+	if !g.repanic || !errors.As(err, &pe) {
+		return
+	}
+	g.mu.Lock()
+	if g.panicked == nil {
+		g.panicked = pe
+	}
+	g.mu.Unlock()
+}
+
+// re-raise the panic kept by `catchPanic`
+func (g *Group) rethrow() {
+	g.mu.Lock()
+	pe := g.panicked
+	g.mu.Unlock()
+	if pe != nil {
+		panic(pe)
+	}
+}
+
+// wrap `f` to convert a panic into `*PanicError`
+func recoverPanic(f func() error) func() error {
+	return func() (err error) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = &PanicError{Value: v, Stack: debug.Stack()}
+			}
+		}()
+		return f()
+	}
+}
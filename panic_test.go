@@ -0,0 +1,82 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestPanicError(t *testing.T) {
+	errBoom := errors.New("panic_test: boom")
+
+	cases := []struct {
+		value interface{}
+		isErr bool
+	}{
+		{value: "panic_test: index out of range"},
+		{value: errBoom, isErr: true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		calls := 0
+		g, ctx := errgroup.NewGroupWithContext(
+			context.Background(),
+			1,
+			false,
+			&errgroup.RetryOption{
+				Mode:       errgroup.Constant,
+				Interval:   time.Millisecond,
+				MaxRetries: 3,
+			},
+			1)
+		g.Go(func() error {
+			calls++
+			panic(tc.value)
+		})
+		errs := g.Wait()
+		if len(errs) != 1 {
+			t.Fatalf("g.Wait() returned %d errors; want 1", len(errs))
+		}
+		var pe *errgroup.PanicError
+		if err := <-errs; !errors.As(err, &pe) {
+			t.Fatalf("g.Wait() = %v; want a *PanicError", err)
+		}
+		if pe.Value != tc.value {
+			t.Errorf("PanicError.Value = %v; want %v", pe.Value, tc.value)
+		}
+		if !strings.Contains(string(pe.Stack), "panic_test.go") {
+			t.Errorf("PanicError.Stack does not contain the panicking func:\n%s", pe.Stack)
+		}
+		if errors.Is(pe, errBoom) != tc.isErr {
+			t.Errorf("errors.Is(%v, errBoom) = %v; want %v", pe, !tc.isErr, tc.isErr)
+		}
+		if calls != 1 {
+			t.Errorf("panicking func called %d times; want 1", calls)
+		}
+		if ctx.Err() == nil {
+			t.Errorf("ctx.Done() was not closed")
+		}
+	}
+}
+
+func TestRepanic(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 1, errgroup.WithRepanic())
+	g.Go(func() error { return nil })
+	g.Go(func() error { panic("panic_test: repanic") })
+
+	defer func() {
+		pe, ok := recover().(*errgroup.PanicError)
+		if !ok {
+			t.Fatalf("g.Wait() did not re-panic with a *PanicError")
+		}
+		if pe.Value != "panic_test: repanic" {
+			t.Errorf("PanicError.Value = %v; want %q", pe.Value, "panic_test: repanic")
+		}
+	}()
+	g.Wait()
+}
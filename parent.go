@@ -0,0 +1,38 @@
+package errgroup
+
+import "context"
+
+// count funcs of the group against concurrency of `parent` as well, so a process-wide group
+// cap all per-request groups derived from it, pass ctx of `parent` to the constructor to be
+// cancelled with it as well, funcs of the group not run in `parent` nor reported by its `Wait`
+func WithParent(parent *Group) Option {
+	return func(g *Group) {
+		g.parent = parent
+	}
+}
+
+// wait for `weight` slots of the group and its ancestors, return func to release them
+func (g *Group) acquireBudget(ctx context.Context, weight int64) (func(), error) {
+	release := func() {}
+	if g.sema != nil {
+		if g.pressure.max > 0 && weight > g.pressure.max {
+			weight = g.pressure.max
+		}
+		if err := g.sema.Acquire(ctx, weight); err != nil {
+			return nil, err
+		}
+		release = func() { g.sema.Release(weight) }
+	}
+	if g.parent == nil {
+		return release, nil
+	}
+	releaseParent, err := g.parent.acquireBudget(ctx, weight)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	return func() {
+		releaseParent()
+		release()
+	}, nil
+}
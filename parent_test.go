@@ -0,0 +1,44 @@
+package errgroup_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestWithParent(t *testing.T) {
+	process, ctx := errgroup.NewGroupWithContext(context.Background(), 3, true, nil, 0)
+	var running, peak int64
+	track := func() error {
+		n := atomic.AddInt64(&running, 1)
+		for {
+			p := atomic.LoadInt64(&peak)
+			if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt64(&running, -1)
+		return nil
+	}
+
+	var requests []*errgroup.Group
+	for i := 0; i < 4; i++ {
+		req, _ := errgroup.NewGroupWithContext(ctx, 2, true, nil, 0, errgroup.WithParent(process))
+		for j := 0; j < 5; j++ {
+			req.Go(track)
+		}
+		requests = append(requests, req)
+	}
+	for _, req := range requests {
+		if errs := req.Wait(); len(errs) > 0 {
+			t.Errorf("req.Wait() = %v; want no error", <-errs)
+		}
+	}
+	if peak > 3 {
+		t.Errorf("%d funcs of request groups ran at a time; want at most 3 of the process group", peak)
+	}
+}
@@ -0,0 +1,62 @@
+package errgroup
+
+import (
+	"context"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// Pool is a partition of a group with its own concurrency limit, see `Group.Pool`
+type Pool struct {
+	g    *Group
+	name string
+	// limit passed to `Group.Pool`
+	n int64
+	// nil mean no own limit
+	sema *semaphore.Weighted
+}
+
+// sub-pool `name` of the group running at most `n` funcs at a time within `maxConcurrency`,
+// e.g. `Pool("db", 5)` and `Pool("s3", 20)`, so exhaustion of one resource class can't starve
+// the others while errors are still reported by `Wait` of the group, a func takes one slot of
+// its pool, with `WithQueue` a func waits for it out of the queue so it takes no worker,
+// the same pool is returned for the same name with the limit of the first call,
+// <= 0 mean no own limit
+func (g *Group) Pool(name string, n int64) *Pool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if p, ok := g.pools[name]; ok {
+		return p
+	}
+	if g.pools == nil {
+		g.pools = make(map[string]*Pool)
+	}
+	p := &Pool{g: g, name: name, n: n}
+	if n > 0 {
+		p.sema = semaphore.NewWeighted(n)
+	}
+	g.pools[name] = p
+	return p
+}
+
+// running unit func in the pool
+func (p *Pool) Go(f func() error) *Task {
+	return p.GoNamedContext("", withoutCtx(f))
+}
+
+// running unit func named `name` in the pool, its error is wrapped as `task "name": err`
+func (p *Pool) GoNamed(name string, f func() error) *Task {
+	return p.GoNamedContext(name, withoutCtx(f))
+}
+
+// running unit func in the pool with the group's ctx carrying settings of the func
+func (p *Pool) GoContext(f func(ctx context.Context) error) *Task {
+	return p.GoNamedContext("", f)
+}
+
+// running unit func named `name` in the pool with the group's ctx, see `Pool.GoNamed`
+func (p *Pool) GoNamedContext(name string, f func(ctx context.Context) error) *Task {
+	t := p.g.newTask(name, f)
+	t.pool = p
+	return p.g.submit(t)
+}
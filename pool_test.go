@@ -0,0 +1,108 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestPool(t *testing.T) {
+	errQuery := errors.New("pool_test: query failed")
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 4, true, nil, 0)
+	db, s3 := g.Pool("db", 1), g.Pool("s3", 3)
+	if g.Pool("db", 10) != db {
+		t.Errorf("g.Pool() returned another pool of the same name")
+	}
+
+	// the db pool is exhausted by a stuck query
+	stuck, release := make(chan struct{}), make(chan struct{})
+	db.Go(func() error {
+		close(stuck)
+		<-release
+		return errQuery
+	})
+	<-stuck
+	var queued int32
+	db.Go(func() error {
+		atomic.AddInt32(&queued, 1)
+		return nil
+	})
+
+	uploaded := make(chan struct{}, 3)
+	for i := 0; i < 3; i++ {
+		s3.Go(func() error {
+			uploaded <- struct{}{}
+			return nil
+		})
+	}
+	for i := 0; i < 3; i++ {
+		<-uploaded
+	}
+	if atomic.LoadInt32(&queued) != 0 {
+		t.Errorf("func of an exhausted pool ran")
+	}
+	close(release)
+
+	errs := g.Wait()
+	if len(errs) != 1 || !errors.Is(<-errs, errQuery) {
+		t.Errorf("g.Wait() did not report %v of the pool", errQuery)
+	}
+	if queued != 1 {
+		t.Errorf("queued func of the pool called %d times; want 1", queued)
+	}
+}
+
+func TestPoolGoContext(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 4, true, nil, 0)
+	errDB := errors.New("pool_test: db down")
+	task := g.Pool("db", 1).GoNamedContext("query", func(ctx context.Context) error {
+		if name, _ := errgroup.TaskName(ctx); name != "query" {
+			t.Errorf("TaskName() = %q; want query", name)
+		}
+		return errDB
+	})
+	<-task.Done()
+	if !errors.Is(task.Err(), errDB) {
+		t.Errorf("task.Err() = %v; want %v", task.Err(), errDB)
+	}
+	<-g.Pool("db", 1).GoContext(func(ctx context.Context) error { return nil }).Done()
+	g.Wait()
+}
+
+func TestPoolQueued(t *testing.T) {
+	for name, opt := range map[string]errgroup.Option{
+		"queue":       errgroup.WithQueue(100),
+		"worker pool": errgroup.WithWorkerPool(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			g, _ := errgroup.NewGroupWithContext(context.Background(), 4, true, nil, 0, opt)
+			// funcs of the saturated pool must not take the workers s3 needs
+			release := make(chan struct{})
+			for i := 0; i < 10; i++ {
+				g.Pool("db", 1).Go(func() error {
+					<-release
+					return nil
+				})
+			}
+			g.Pool("s3", 5).Go(func() error {
+				close(release)
+				return nil
+			})
+			done := make(chan struct{})
+			go func() {
+				g.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				close(release)
+				t.Fatalf("func of pool s3 waits for funcs of pool db")
+			}
+		})
+	}
+}
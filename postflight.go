@@ -0,0 +1,21 @@
+package errgroup
+
+import "context"
+
+// run `fn` once after all funcs returned but before `Wait` return, error of `fn` is
+// reported by `Wait` together with errors of funcs
+// use to check invariants of the whole group, like output count equals input count
+func WithPostflight(fn func(ctx context.Context, s Summary) error) Option {
+	return func(g *Group) {
+		g.postflight = fn
+	}
+}
+
+func (g *Group) runPostflight() {
+	if g.postflight == nil {
+		return
+	}
+	if err := g.postflight(g.ctx, g.Summary()); err != nil {
+		g.putErr(err)
+	}
+}
@@ -0,0 +1,51 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestPostflight(t *testing.T) {
+	errTask := errors.New("postflight_test: task failed")
+	errCheck := errors.New("postflight_test: row count mismatch")
+
+	var got errgroup.Summary
+	g, _ := errgroup.NewGroupWithContext(
+		context.Background(),
+		2,
+		true,
+		nil,
+		3,
+		errgroup.WithPostflight(func(ctx context.Context, s errgroup.Summary) error {
+			if ctx.Err() != nil {
+				t.Errorf("postflight ctx was cancelled before Wait returned")
+			}
+			got = s
+			if s.Succeeded != s.Submitted {
+				return errCheck
+			}
+			return nil
+		}))
+	for _, err := range []error{nil, errTask, nil} {
+		err := err
+		g.Go(func() error { return err })
+	}
+
+	errs := g.Wait()
+	want := errgroup.Summary{Submitted: 3, Succeeded: 2, Failed: 1}
+	if got != want {
+		t.Errorf("postflight summary = %+v; want %+v", got, want)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("g.Wait() returned %d errors; want 2", len(errs))
+	}
+	if err := <-errs; err != errTask {
+		t.Errorf("first error = %v; want %v", err, errTask)
+	}
+	if err := <-errs; err != errCheck {
+		t.Errorf("second error = %v; want %v", err, errCheck)
+	}
+}
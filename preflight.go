@@ -0,0 +1,36 @@
+package errgroup
+
+import (
+	"context"
+	"fmt"
+)
+
+// run `fn` once before any func call of the group, `retryMode` define how to retry `fn`
+// funcs start only after `fn` succeeded, once it failed (after retries) the error is
+// reported by `Wait`, ctx is cancelled and no func will be called
+func WithPreflight(fn func(ctx context.Context) error, retryMode *RetryOption) Option {
+	return func(g *Group) {
+		g.preflight = fn
+		g.preflightRetry = retryMode.clone()
+	}
+}
+
+func (g *Group) startPreflight() {
+	if g.preflight == nil {
+		return
+	}
+	g.ready = make(chan struct{})
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer close(g.ready)
+		// panics are reported like those of funcs, see `PanicError`
+		_, _, err := retry(recoverPanic(func() error { return g.preflight(g.ctx) }), g.preflightRetry, false, nil)()
+		if err != nil {
+			g.aborted = true
+			g.catchPanic(err)
+			g.putErr(err)
+			g.cancel(fmt.Errorf("errgroup: preflight failed: %w", err))
+		}
+	}()
+}
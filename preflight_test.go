@@ -0,0 +1,88 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestPreflight(t *testing.T) {
+	errDial := errors.New("preflight_test: dial failed")
+
+	cases := []struct {
+		fails   int32
+		retries int64
+		want    error
+		calls   int32
+	}{
+		{fails: 0, retries: 0, want: nil, calls: 3},
+		{fails: 2, retries: 3, want: nil, calls: 3},
+		{fails: 5, retries: 1, want: errDial, calls: 0},
+	}
+
+	for _, tc := range cases {
+		var attempts, calls int32
+		g, ctx := errgroup.NewGroupWithContext(
+			context.Background(),
+			2,
+			true,
+			nil,
+			3,
+			errgroup.WithPreflight(func(context.Context) error {
+				if atomic.AddInt32(&attempts, 1) <= tc.fails {
+					return errDial
+				}
+				return nil
+			}, &errgroup.RetryOption{
+				Mode:       errgroup.Constant,
+				Interval:   time.Millisecond,
+				MaxRetries: tc.retries,
+			}))
+		for i := 0; i < 3; i++ {
+			g.Go(func() error {
+				atomic.AddInt32(&calls, 1)
+				return nil
+			})
+		}
+		errs := g.Wait()
+		if len(errs) > 1 {
+			t.Errorf("preflight failure reported %d errors; want at most 1", len(errs))
+		}
+		var got error
+		if len(errs) > 0 {
+			got = <-errs
+		}
+		if !errors.Is(got, tc.want) {
+			t.Errorf("g.Wait() = %v; want %v", got, tc.want)
+		}
+		if calls != tc.calls {
+			t.Errorf("funcs called %d times; want %d", calls, tc.calls)
+		}
+		if ctx.Err() == nil {
+			t.Errorf("ctx.Done() was not closed")
+		}
+	}
+}
+
+func TestPreflightPanic(t *testing.T) {
+	var calls int32
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0,
+		errgroup.WithPreflight(func(context.Context) error {
+			panic("preflight_test: boom")
+		}, nil))
+	g.Go(func() error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	})
+	var pe *errgroup.PanicError
+	if err := <-g.Wait(); !errors.As(err, &pe) || pe.Value != "preflight_test: boom" {
+		t.Errorf("g.Wait() = %v; want PanicError", err)
+	}
+	if calls != 0 {
+		t.Errorf("funcs called %d times; want 0", calls)
+	}
+}
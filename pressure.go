@@ -0,0 +1,104 @@
+package errgroup
+
+import (
+	"sync"
+	"time"
+)
+
+type PressureLevel uint8
+
+const (
+	// no func is waiting for a slot
+	Low PressureLevel = iota
+	// some funcs are waiting for a slot, but fewer than `maxConcurrency`
+	Medium
+	// as many funcs as `maxConcurrency` > 0 are waiting, or funcs wait for a slot longer than they run
+	High
+)
+
+func (l PressureLevel) String() string {
+	switch l {
+	case Low:
+		return "low"
+	case Medium:
+		return "medium"
+	default:
+		return "high"
+	}
+}
+
+// weight of the latest sample in moving averages of wait and run time
+const pressureAlpha = 0.2
+
+// track queue depth and slot wait times of a group with `maxConcurrency`
+type pressure struct {
+	mu      sync.Mutex
+	max     int64
+	waiting int64
+	// moving averages of time waited for a slot and time a slot was held, `run` is 0 until a slot is released
+	wait, run float64
+	level     PressureLevel
+	ch        chan PressureLevel
+}
+
+// signal how much funcs queue for concurrency slots, so producers feeding the group can
+// throttle instead of blocking in `Go` unpredictably, a level is sent once it changes
+// slow receivers only get the latest level, always `Low` if `maxConcurrency` <= 0 without `WithLimiter`,
+// with `WithLimiter` only funcs waiting longer than they run raise it to `High`
+func (g *Group) Pressure() <-chan PressureLevel {
+	g.pressure.mu.Lock()
+	defer g.pressure.mu.Unlock()
+	if g.pressure.ch == nil {
+		g.pressure.ch = make(chan PressureLevel, 1)
+		g.pressure.ch <- g.pressure.level
+	}
+	return g.pressure.ch
+}
+
+// a func start waiting for a slot
+func (p *pressure) enqueue() {
+	p.mu.Lock()
+	p.waiting++
+	p.update()
+	p.mu.Unlock()
+}
+
+// a func got a slot or gave up after waiting `d`
+func (p *pressure) dequeue(d time.Duration) {
+	p.mu.Lock()
+	p.waiting--
+	p.wait += pressureAlpha * (float64(d) - p.wait)
+	p.update()
+	p.mu.Unlock()
+}
+
+// a func released its slot after holding it for `d`
+func (p *pressure) release(d time.Duration) {
+	p.mu.Lock()
+	p.run += pressureAlpha * (float64(d) - p.run)
+	p.update()
+	p.mu.Unlock()
+}
+
+// must hold `p.mu`
+func (p *pressure) update() {
+	level := Low
+	switch {
+	case (p.max > 0 && p.waiting >= p.max) || (p.waiting > 0 && p.run > 0 && p.wait > p.run):
+		level = High
+	case p.waiting > 0:
+		level = Medium
+	}
+	if level == p.level {
+		return
+	}
+	p.level = level
+	if p.ch == nil {
+		return
+	}
+	select {
+	case <-p.ch:
+	default:
+	}
+	p.ch <- level
+}
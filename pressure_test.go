@@ -0,0 +1,76 @@
+package errgroup_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestPressure(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 1)
+	pressure := g.Pressure()
+	if level := <-pressure; level != errgroup.Low {
+		t.Fatalf("initial pressure = %v; want %v", level, errgroup.Low)
+	}
+
+	release := make(chan struct{})
+	for i := 0; i < 3; i++ {
+		g.Go(func() error {
+			<-release
+			return nil
+		})
+	}
+
+	// 1 func holds the only slot and 2 wait for it
+	deadline := time.After(time.Second)
+	for level := errgroup.Low; level != errgroup.High; {
+		select {
+		case level = <-pressure:
+		case <-deadline:
+			t.Fatalf("pressure did not reach %v", errgroup.High)
+		}
+	}
+	close(release)
+	g.Wait()
+
+	select {
+	case level := <-pressure:
+		if level != errgroup.Low {
+			t.Errorf("pressure after Wait = %v; want %v", level, errgroup.Low)
+		}
+	default:
+		t.Errorf("pressure did not go back to %v", errgroup.Low)
+	}
+}
+
+func TestPressureWithLimiter(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0, errgroup.WithLimiter(&countingLimiter{}))
+	pressure := g.Pressure()
+	done := make(chan struct{})
+	high := make(chan bool, 1)
+	go func() {
+		seen := false
+		for {
+			select {
+			case level := <-pressure:
+				seen = seen || level == errgroup.High
+			case <-done:
+				high <- seen
+				return
+			}
+		}
+	}()
+	for i := 0; i < 3; i++ {
+		g.Go(func() error {
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+	}
+	g.Wait()
+	close(done)
+	if <-high {
+		t.Errorf("pressure reached %v with a limiter that never blocks", errgroup.High)
+	}
+}
@@ -0,0 +1,264 @@
+package errgroup
+
+import (
+	"sync"
+	"time"
+)
+
+// run funcs by at most `maxConcurrency` goroutines, funcs waiting for a slot are kept in a queue
+// of `size` funcs instead of a parked goroutine each, so memory stays flat however fast funcs
+// are passed, `Go` block once the queue is full unless `WithQueueFullPolicy`, <= 0 mean no limit
+// funcs passing funcs to the same group may dead lock once it's full, ignored if
+// `maxConcurrency` <= 0
+func WithQueue(size int) Option {
+	return func(g *Group) {
+		if q := g.useQueue(); q != nil {
+			q.size = size
+		}
+	}
+}
+
+// run funcs by `maxConcurrency` long-lived workers pulling funcs from the queue, instead of a
+// goroutine per func, for many short funcs, workers start with the first func and exit once the
+// group completed, see `Wait`, the queue is unbounded unless `WithQueue`, see `WorkerID`
+// ignored if `maxConcurrency` <= 0
+func WithWorkerPool() Option {
+	return func(g *Group) {
+		if q := g.useQueue(); q != nil {
+			q.pool = true
+		}
+	}
+}
+
+// queue of the group, created if not yet, nil if `maxConcurrency` <= 0
+func (g *Group) useQueue() *queue {
+	if g.pressure.max <= 0 {
+		return nil
+	}
+	if g.queue == nil {
+		q := &queue{max: g.pressure.max}
+		q.notFull = sync.NewCond(&q.mu)
+		q.notEmpty = sync.NewCond(&q.mu)
+		g.queue = q
+	}
+	return g.queue
+}
+
+// a func waiting in the queue
+type job struct {
+	t   *task
+	fun func() (int64, bool, error)
+	// when and as which one it was queued
+	at  time.Time
+	seq uint64
+	// receive the error of a run of `GoEvery` instead of finishing the func, see `runStep`
+	step chan error
+}
+
+// funcs waiting for one of at most `max` goroutines, a goroutine keep running queued funcs
+// until the queue is empty, or until the group completed with `pool`
+type queue struct {
+	mu       sync.Mutex
+	notFull  *sync.Cond
+	notEmpty *sync.Cond
+	// created by the first queued func due to `order` and `fair`
+	jobs    jobList
+	size    int
+	workers int64
+	max     int64
+	// see `WithWorkerPool`
+	pool    bool
+	started bool
+	closed  bool
+	// see `WithQueueFullPolicy`
+	full QueueFullPolicy
+	// see `WithDispatchOrder`
+	order DispatchOrder
+	// see `WithFairKeys`
+	fair bool
+	// number of funcs queued so far
+	seq uint64
+}
+
+// queued funcs in the order to run them
+type jobList interface {
+	add(j job)
+	// remove the func to run next
+	next() job
+	// remove the func queued first
+	oldest() job
+	len() int
+}
+
+// queue `t`, funcs which must wait before they can run wait out of the queue meanwhile, so
+// they take no worker, `run` check again why they waited and report it, the ticker loop of
+// `GoEvery` takes no worker either, every run is queued on its own, see `runStep`
+func (g *Group) enqueue(t *task, fun func() (int64, bool, error)) {
+	if t.delay <= 0 && t.laneWait == nil && t.every <= 0 && len(g.depsOf(t)) == 0 && !g.bulkheaded(t) {
+		g.queue.push(g, job{t: t, fun: fun})
+		return
+	}
+	go func() {
+		g.sleep(t)
+		if t.laneWait != nil {
+			<-t.laneWait
+		}
+		g.waitDeps(t)
+		if t.every > 0 {
+			g.exec(t, fun)
+			return
+		}
+		g.hold(t)
+		g.queue.push(g, job{t: t, fun: fun})
+	}()
+}
+
+// true if `t` may wait for a slot of its pool or key, see `hold`
+func (g *Group) bulkheaded(t *task) bool {
+	return (t.pool != nil && t.pool.sema != nil) || (t.slotKey != "" && g.keys != nil)
+}
+
+// take slots of the pool and key of `t` before it's queued, so a saturated pool or key not
+// hold workers other pools and keys need, `acquire` take them again if it failed
+func (g *Group) hold(t *task) {
+	if !g.bulkheaded(t) {
+		return
+	}
+	if release, err := g.acquireBulkhead(g.slotCtx(t), t); err == nil {
+		t.held = release
+	}
+}
+
+// run `j` in a new goroutine or queue it, once the queue is full block or reject a func due to policy
+func (q *queue) push(g *Group, j job) {
+	if rejected := q.put(g, j); rejected != nil {
+		g.rejectJob(*rejected)
+	}
+}
+
+// must not hold `q.mu`
+func (q *queue) put(g *Group, j job) *job {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.pool && !q.started {
+		q.started = true
+		for ; q.workers < q.max; q.workers++ {
+			go q.work(g, nil, int(q.workers)+1)
+		}
+	}
+	for {
+		if q.workers < q.max {
+			q.workers++
+			go q.work(g, &j, 0)
+			return nil
+		}
+		if q.size <= 0 || q.len() < q.size {
+			q.add(g, j)
+			q.notEmpty.Signal()
+			return nil
+		}
+		switch q.full {
+		case RejectNewest:
+			return &j
+		case RejectOldest:
+			oldest := q.jobs.oldest()
+			g.pressure.dequeue(time.Since(oldest.at))
+			q.add(g, j)
+			return &oldest
+		}
+		q.notFull.Wait()
+	}
+}
+
+// must hold `q.mu`
+func (q *queue) add(g *Group, j job) {
+	if q.jobs == nil {
+		if q.fair {
+			q.jobs = &fairJobs{lifo: q.order == LIFO, keys: make(map[string]*jobSlice)}
+		} else {
+			q.jobs = &jobSlice{lifo: q.order == LIFO}
+		}
+	}
+	q.seq++
+	j.at, j.seq = time.Now(), q.seq
+	q.jobs.add(j)
+	g.pressure.enqueue()
+}
+
+// must hold `q.mu`
+func (q *queue) len() int {
+	if q.jobs == nil {
+		return 0
+	}
+	return q.jobs.len()
+}
+
+// run `j` if not nil and then queued funcs until the goroutine is no longer needed, `id` of
+// workers of the pool count from 1, 0 for other goroutines
+func (q *queue) work(g *Group, j *job, id int) {
+	for {
+		if j != nil {
+			j.t.worker = id
+		}
+		switch {
+		case j != nil && j.step != nil:
+			j.step <- g.step(j.t, j.fun)
+		case j != nil:
+			g.exec(j.t, j.fun)
+		}
+		q.mu.Lock()
+		for q.len() == 0 && q.pool && !q.closed {
+			q.notEmpty.Wait()
+		}
+		if q.len() == 0 {
+			q.workers--
+			q.mu.Unlock()
+			return
+		}
+		next := q.jobs.next()
+		g.pressure.dequeue(time.Since(next.at))
+		q.notFull.Signal()
+		q.mu.Unlock()
+		j = &next
+	}
+}
+
+// stop idle workers once the group completed, later funcs are run as without `WithWorkerPool`
+func (q *queue) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+	q.notEmpty.Broadcast()
+}
+
+// queued funcs in FIFO or LIFO order
+type jobSlice struct {
+	jobs []job
+	lifo bool
+}
+
+func (s *jobSlice) add(j job) {
+	s.jobs = append(s.jobs, j)
+}
+
+func (s *jobSlice) next() job {
+	if !s.lifo {
+		return s.oldest()
+	}
+	last := len(s.jobs) - 1
+	j := s.jobs[last]
+	s.jobs[last] = job{}
+	s.jobs = s.jobs[:last]
+	return j
+}
+
+func (s *jobSlice) oldest() job {
+	j := s.jobs[0]
+	s.jobs[0] = job{}
+	s.jobs = s.jobs[1:]
+	return j
+}
+
+func (s *jobSlice) len() int {
+	return len(s.jobs)
+}
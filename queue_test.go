@@ -0,0 +1,84 @@
+package errgroup_test
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestQueue(t *testing.T) {
+	before := runtime.NumGoroutine()
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0, errgroup.WithQueue(4))
+	release := make(chan struct{})
+	var calls int32
+	f := func() error {
+		<-release
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}
+	for i := 0; i < 6; i++ {
+		g.Go(f)
+	}
+	if n := runtime.NumGoroutine() - before; n > 2 {
+		t.Errorf("%d goroutines spawned for 6 funcs; want at most 2", n)
+	}
+
+	submitted := make(chan struct{})
+	go func() {
+		g.Go(f)
+		close(submitted)
+	}()
+	select {
+	case <-submitted:
+		t.Fatalf("g.Go() returned with the queue full; want it blocked")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	<-submitted
+	if errs := g.Wait(); len(errs) > 0 {
+		t.Errorf("g.Wait() = %v; want no error", <-errs)
+	}
+	if calls != 7 {
+		t.Errorf("funcs called %d times; want 7", calls)
+	}
+}
+
+func TestWorkerPool(t *testing.T) {
+	before := runtime.NumGoroutine()
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0, errgroup.WithWorkerPool())
+	var (
+		calls int32
+		peak  int64
+	)
+	for i := 0; i < 100; i++ {
+		g.Go(func() error {
+			atomic.AddInt32(&calls, 1)
+			if n := int64(runtime.NumGoroutine() - before); n > atomic.LoadInt64(&peak) {
+				atomic.StoreInt64(&peak, n)
+			}
+			return nil
+		})
+	}
+	if errs := g.Wait(); len(errs) > 0 {
+		t.Errorf("g.Wait() = %v; want no error", <-errs)
+	}
+	if calls != 100 {
+		t.Errorf("funcs called %d times; want 100", calls)
+	}
+	if peak > 2 {
+		t.Errorf("%d goroutines ran 100 funcs; want 2 workers", peak)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if n := runtime.NumGoroutine() - before; n > 0 {
+		t.Errorf("%d workers still running after g.Wait()", n)
+	}
+}
@@ -0,0 +1,18 @@
+package errgroup
+
+import "context"
+
+// RateLimiter throttle attempts of funcs, `*rate.Limiter` of golang.org/x/time/rate satisfy it
+type RateLimiter interface {
+	// block until an attempt is allowed or return error, e.g. once ctx is cancelled
+	Wait(ctx context.Context) error
+}
+
+// throttle every attempt of funcs (retries included) by `l` in addition to `maxConcurrency`,
+// as APIs are usually quota'd by QPS rather than parallelism, e.g. 100 per second with
+// `rate.NewLimiter(100, 1)`, an attempt not allowed fail with the error of `l.Wait`
+func WithRateLimit(l RateLimiter) Option {
+	return func(g *Group) {
+		g.rate = l
+	}
+}
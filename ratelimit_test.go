@@ -0,0 +1,58 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+// allow an attempt per tick
+type tickLimiter struct {
+	ticks <-chan time.Time
+	waits int32
+}
+
+func (l *tickLimiter) Wait(ctx context.Context) error {
+	atomic.AddInt32(&l.waits, 1)
+	select {
+	case <-l.ticks:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	errFlaky := errors.New("ratelimit_test: flaky")
+	ticker := time.NewTicker(5 * time.Millisecond)
+	defer ticker.Stop()
+	l := &tickLimiter{ticks: ticker.C}
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, &errgroup.RetryOption{
+		Mode:       errgroup.Constant,
+		MaxRetries: 1,
+	}, 0, errgroup.WithRateLimit(l))
+
+	start := time.Now()
+	var calls int32
+	for i := 0; i < 4; i++ {
+		g.Go(func() error {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return errFlaky
+			}
+			return nil
+		})
+	}
+	if errs := g.Wait(); len(errs) > 0 {
+		t.Errorf("g.Wait() = %v; want no error", <-errs)
+	}
+	if l.waits != 5 {
+		t.Errorf("rate limiter waited %d times for 4 funcs and a retry; want 5", l.waits)
+	}
+	if d := time.Since(start); d < 20*time.Millisecond {
+		t.Errorf("5 attempts at 1 per 5ms took %v; want at least 20ms", d)
+	}
+}
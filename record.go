@@ -0,0 +1,25 @@
+package errgroup
+
+import "time"
+
+// ErrorRecord is a collected error with when it occurred
+type ErrorRecord struct {
+	// increase by one for every collected error, ones dropped due to `maxErrs` included
+	Seq uint64
+	// when the error first occurred
+	Time time.Time
+	// same error as returned by `Wait`
+	Err error
+}
+
+// collected errors in the order they occurred, to reconstruct which func failed first and
+// triggered cancellation, errors not dropped by `maxErrs` are the same as returned by `Wait`
+func (g *Group) ErrorRecords() []ErrorRecord {
+	g.err.mu.Lock()
+	defer g.err.mu.Unlock()
+	records := make([]ErrorRecord, 0, len(g.err.errs))
+	for _, entry := range g.err.errs {
+		records = append(records, ErrorRecord{Seq: entry.seq, Time: entry.at, Err: entry.error()})
+	}
+	return records
+}
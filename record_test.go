@@ -0,0 +1,35 @@
+package errgroup_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestErrorRecords(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 3, errgroup.WithErrOverflow(errgroup.DropOldest))
+	for i := 0; i < 5; i++ {
+		i := i
+		g.Go(func() error { return fmt.Errorf("record_test: %d", i) })
+		g.Wait()
+	}
+
+	records := g.ErrorRecords()
+	if len(records) != 3 {
+		t.Fatalf("got %d records; want 3", len(records))
+	}
+	errs := g.Wait()
+	for i, r := range records {
+		if want := uint64(i + 3); r.Seq != want {
+			t.Errorf("record %d has seq %d; want %d", i, r.Seq, want)
+		}
+		if i > 0 && r.Time.Before(records[i-1].Time) {
+			t.Errorf("record %d occurred before record %d", i, i-1)
+		}
+		if err := <-errs; err != r.Err {
+			t.Errorf("record %d = %v; want %v as returned by Wait", i, r.Err, err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package errgroup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cenkalti/backoff"
+)
+
+// Codec marshal payload of funcs run by remote workers
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+}
+
+// JSONCodec marshal payload with `encoding/json`
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Dispatcher send work to remote workers (over http, grpc, a queue...) and block until
+// the remote worker finished it, the returned error is reported as the func's error
+type Dispatcher interface {
+	Dispatch(ctx context.Context, key string, payload []byte) error
+}
+
+// run the work identified by `key` on remote workers through `d`, `payload` is marshaled
+// by `c` at once so later changes of it are not sent
+// the group track completion and errors like any other func, retry included, so the same
+// orchestration code can scale beyond one process
+func (g *Group) GoRemote(d Dispatcher, c Codec, key string, payload interface{}) *Task {
+	data, err := c.Marshal(payload)
+	// ctx of the func carry its cancellation, deadline and hooks to `d`
+	return g.submit(g.newTask("", func(ctx context.Context) error {
+		if err != nil {
+			return backoff.Permanent(fmt.Errorf("remote %q: marshal payload: %w", key, err))
+		}
+		if err := d.Dispatch(ctx, key, data); err != nil {
+			return fmt.Errorf("remote %q: %w", key, err)
+		}
+		return nil
+	}))
+}
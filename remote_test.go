@@ -0,0 +1,84 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+type fakeDispatcher struct {
+	mu       sync.Mutex
+	payloads map[string]string
+	fail     map[string]error
+}
+
+func (d *fakeDispatcher) Dispatch(_ context.Context, key string, payload []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.payloads[key] = string(payload)
+	return d.fail[key]
+}
+
+func TestGoRemote(t *testing.T) {
+	errWorker := errors.New("remote_test: worker crashed")
+	d := &fakeDispatcher{
+		payloads: map[string]string{},
+		fail:     map[string]error{"resize": errWorker},
+	}
+
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 3)
+	g.GoRemote(d, errgroup.JSONCodec{}, "thumbnail", map[string]int{"width": 64})
+	g.GoRemote(d, errgroup.JSONCodec{}, "resize", []int{1, 2})
+	g.GoRemote(d, errgroup.JSONCodec{}, "broken", make(chan int))
+
+	errs := g.Wait()
+	if len(errs) != 2 {
+		t.Fatalf("g.Wait() returned %d errors; want 2", len(errs))
+	}
+	var sawWorker bool
+	for i := 0; i < 2; i++ {
+		if errors.Is(<-errs, errWorker) {
+			sawWorker = true
+		}
+	}
+	if !sawWorker {
+		t.Errorf("g.Wait() did not report the remote worker's error")
+	}
+	if got := d.payloads["thumbnail"]; got != `{"width":64}` {
+		t.Errorf("dispatched payload = %s; want %s", got, `{"width":64}`)
+	}
+	if _, ok := d.payloads["broken"]; ok {
+		t.Errorf("payload failed to marshal was dispatched")
+	}
+}
+
+// block until ctx passed to `Dispatch` is done
+type blockingDispatcher struct {
+	deadline chan bool
+}
+
+func (d blockingDispatcher) Dispatch(ctx context.Context, key string, payload []byte) error {
+	_, ok := ctx.Deadline()
+	d.deadline <- ok
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestGoRemoteTaskCtx(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0, errgroup.WithTaskTimeout(time.Minute))
+	d := blockingDispatcher{deadline: make(chan bool, 1)}
+	task := g.GoRemote(d, errgroup.JSONCodec{}, "resize", 1)
+	if !<-d.deadline {
+		t.Errorf("ctx passed to Dispatch has no deadline of WithTaskTimeout")
+	}
+	task.Cancel()
+	<-task.Done()
+	if !errors.Is(task.Err(), errgroup.ErrTaskCancelled) {
+		t.Errorf("task.Err() = %v; want ErrTaskCancelled", task.Err())
+	}
+	g.Wait()
+}
@@ -0,0 +1,56 @@
+package errgroup
+
+import (
+	"context"
+	"errors"
+)
+
+// arguments of `NewGroupWithContext`
+type params struct {
+	maxConcurrency int64
+	waitAll        bool
+	retryMode      *RetryOption
+	maxErrs        int
+	opts           []Option
+}
+
+// create a new group with the same settings, `opts` applied after the original ones, and pass
+// it only the funcs failed in this group, so failures of a batch can be re-run at once
+// funcs keep their idempotency keys, funcs passed by `Go` keep using the ctx they captured,
+// pass funcs taking ctx (e.g. `GoContext`) to get ctx of the new group, must be called after `Wait`
+func (g *Group) RetryFailed(ctx context.Context, opts ...Option) (*Group, error) {
+	g.cancelMu.Lock()
+	completed := g.completed
+	g.cancelMu.Unlock()
+	if !completed {
+		return nil, errors.New("errgroup: RetryFailed called before Wait")
+	}
+
+	p := g.params
+	ng, _ := NewGroupWithContext(ctx, p.maxConcurrency, p.waitAll, p.retryMode, p.maxErrs, append(p.opts[:len(p.opts):len(p.opts)], opts...)...)
+	g.mu.Lock()
+	failed := append([]*task(nil), g.failed...)
+	g.mu.Unlock()
+	for _, t := range failed {
+		ng.submit(ng.rerun(g, t))
+	}
+	return ng, nil
+}
+
+// a task of this group with the settings `t` was passed to `old` with
+func (g *Group) rerun(old *Group, t *task) *task {
+	c := g.newTask(t.name, t.fn)
+	if t.retryMode != old.retryMode {
+		c.retryMode = t.retryMode
+	}
+	if t.timeout != old.taskTimeout {
+		c.timeout = t.timeout
+	}
+	c.weight, c.optional, c.key = t.weight, t.optional, t.key
+	c.tags, c.slotKey = t.tags, t.slotKey
+	c.delay, c.every, c.parent = t.delay, t.every, t.parent
+	if t.pool != nil {
+		c.pool = g.Pool(t.pool.name, t.pool.n)
+	}
+	return c
+}
@@ -0,0 +1,124 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestRetryFailed(t *testing.T) {
+	errFlaky := errors.New("rerun_test: flaky")
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0)
+	if _, err := g.RetryFailed(context.Background()); err == nil {
+		t.Errorf("g.RetryFailed() before g.Wait() returned no error")
+	}
+
+	var ok, flaky, keys int32
+	key := ""
+	g.Go(func() error {
+		atomic.AddInt32(&ok, 1)
+		return nil
+	})
+	g.GoContext(func(ctx context.Context) error {
+		k, _ := errgroup.IdempotencyKey(ctx)
+		if atomic.AddInt32(&flaky, 1) == 1 {
+			key = k
+			return errFlaky
+		}
+		if k == key {
+			atomic.AddInt32(&keys, 1)
+		}
+		return nil
+	})
+	if errs := g.Wait(); len(errs) != 1 {
+		t.Fatalf("g.Wait() returned %d errors; want 1", len(errs))
+	}
+
+	rerun, err := g.RetryFailed(context.Background(), errgroup.WithErrorDedup())
+	if err != nil {
+		t.Fatalf("g.RetryFailed() = %v; want no error", err)
+	}
+	if errs := rerun.Wait(); len(errs) > 0 {
+		t.Errorf("rerun.Wait() = %v; want no error", <-errs)
+	}
+	if ok != 1 || flaky != 2 {
+		t.Errorf("succeeded func called %d times, failed one %d times; want 1 and 2", ok, flaky)
+	}
+	if keys != 1 {
+		t.Errorf("re-run func got another idempotency key")
+	}
+	if s := rerun.Summary(); s.Submitted != 1 || s.Succeeded != 1 {
+		t.Errorf("rerun.Summary() = %+v; want 1 func submitted and succeeded", s)
+	}
+}
+
+func TestRetryFailedEvery(t *testing.T) {
+	errFlaky := errors.New("rerun_test: flaky")
+	ctx, cancel := context.WithCancel(context.Background())
+	g, _ := errgroup.NewGroupWithContext(ctx, 2, true, nil, 0)
+	var runs int32
+	g.GoEvery(time.Millisecond, func(context.Context) error {
+		n := atomic.AddInt32(&runs, 1)
+		if n == 3 {
+			cancel()
+		}
+		if n <= 3 {
+			return errFlaky
+		}
+		return nil
+	})
+	if errs := g.Wait(); len(errs) != 3 {
+		t.Fatalf("g.Wait() returned %d errors; want 3", len(errs))
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	rerun, _ := g.RetryFailed(ctx)
+	// stop the loop once it ran again
+	rerun.Go(func() error {
+		for atomic.LoadInt32(&runs) < 4 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+		return nil
+	})
+	rerun.Wait()
+	if s := rerun.Summary(); s.Submitted != 2 {
+		t.Errorf("rerun.Summary().Submitted = %d; want 2, the failing loop passed once", s.Submitted)
+	}
+}
+
+func TestRetryFailedPool(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0,
+		errgroup.WithLateSubmissionPolicy(errgroup.Queue))
+	g.Pool("db", 1).Go(func() error {
+		return errors.New("rerun_test: db down")
+	})
+	g.Wait()
+
+	// hold the only slot of the pool in the old group
+	started, release := make(chan struct{}), make(chan struct{})
+	g.Pool("db", 1).Go(func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+	defer g.Wait()
+	defer close(release)
+
+	rerun, _ := g.RetryFailed(context.Background())
+	done := make(chan struct{})
+	go func() {
+		rerun.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("re-run func waits for the pool of the old group")
+	}
+}
@@ -0,0 +1,185 @@
+package errgroup
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+type RetryMode uint8
+
+const (
+	// not to retry
+	Zero RetryMode = iota
+	// use constant time duration mode to retry
+	Constant
+	// use exponential duration mode to retry
+	Exponential
+)
+
+// pick the backoff policy by error of the failed attempt, return nil to stop retrying
+// it's called after every failed attempt and may be called from many goroutines at the same time
+type BackoffSelector func(err error) backoff.BackOff
+
+// use to retry for every func call
+type RetryOption struct {
+	// choose mode to your retry mode
+	Mode RetryMode
+	// only work when choose `Constant` retry mode
+	Interval time.Duration
+	// max retry times
+	MaxRetries int64
+	// map error classes to different backoff policies, `Mode` and `Interval` are ignored once set
+	// e.g. exponential for timeouts, server provided delay for rate limits and no retry for others
+	Selector BackoffSelector
+	// only retry errors it returns true for, e.g. `IsTransient`, nil mean retry all errors
+	RetryIf func(err error) bool
+}
+
+// retry quickly and many times, for cheap calls against a dependency expected to recover fast
+func RetryAggressive() *RetryOption {
+	return &RetryOption{
+		Mode:       Constant,
+		Interval:   time.Millisecond * 10,
+		MaxRetries: 10,
+	}
+}
+
+// retry a few times with growing intervals, for expensive calls or dependencies easy to overload
+func RetryConservative() *RetryOption {
+	return &RetryOption{
+		Mode:       Exponential,
+		MaxRetries: 2,
+	}
+}
+
+// retry common network calls like http or rpc requests
+func RetryNetworkDefault() *RetryOption {
+	return &RetryOption{
+		Mode:       Constant,
+		Interval:   time.Millisecond * 30,
+		MaxRetries: 3,
+	}
+}
+
+// copy `r` so later changes of the caller not affect running groups
+func (r *RetryOption) clone() *RetryOption {
+	if r == nil {
+		return nil
+	}
+	c := *r
+	return &c
+}
+
+// backoff policy due to `Mode`
+func (r *RetryOption) backOff() backoff.BackOff {
+	switch r.Mode {
+	case Constant:
+		return backoff.NewConstantBackOff(r.Interval)
+	case Exponential:
+		return backoff.NewExponentialBackOff()
+	default:
+		return &backoff.StopBackOff{}
+	}
+}
+
+// shift constant backoff schedule of every func by a random fraction of `Interval`, so
+// funcs failed at the same time not retry in synchronized waves
+func WithRetryDesync() Option {
+	return func(g *Group) {
+		g.desync = true
+	}
+}
+
+// decide whether and when to retry after a failed attempt of one func call
+type retrier struct {
+	r       *RetryOption
+	desync  bool
+	policy  backoff.BackOff
+	retries int64
+}
+
+func newRetrier(r *RetryOption, desync bool) *retrier {
+	rt := &retrier{r: r, desync: desync}
+	if r.Selector == nil {
+		rt.policy = r.backOff()
+	}
+	return rt
+}
+
+// return the delay before next attempt after `err`, false mean stop retrying
+func (rt *retrier) next(err error) (time.Duration, bool) {
+	switch err.(type) {
+	case *backoff.PermanentError, *PanicError:
+		return 0, false
+	}
+	if rt.retries >= rt.r.MaxRetries {
+		return 0, false
+	}
+	if rt.r.RetryIf != nil && !rt.r.RetryIf(err) {
+		return 0, false
+	}
+	policy := rt.policy
+	if rt.r.Selector != nil {
+		if policy = rt.r.Selector(err); policy == nil {
+			return 0, false
+		}
+	}
+	next := policy.NextBackOff()
+	if next == backoff.Stop {
+		return 0, false
+	}
+	if rt.desync && rt.retries == 0 && rt.r.Selector == nil && rt.r.Mode == Constant && next > 0 {
+		next += time.Duration(rand.Int63n(int64(next)))
+	}
+	rt.retries++
+	return next, true
+}
+
+// true mean stopped because all retries were used
+func (rt *retrier) exhausted() bool {
+	return rt.retries > 0 && rt.retries >= rt.r.MaxRetries
+}
+
+// wrap `f` with the retry policy described by `r`, nil `r` mean call `f` only once
+// return `backoff.Permanent(err)` in `f` to stop retrying, `*PanicError` is never retried
+// `desync` shift the first delay of `Constant` mode by a random fraction of `Interval`
+// `notify` is called with the error and the delay before every retry if not nil
+// the returned func report how many retries were made and whether it failed after all retries were used,
+// in which case the error is wrapped with `ErrRetriesExhausted`
+func retry(f func() error, r *RetryOption, desync bool, notify backoff.Notify) func() (retries int64, exhausted bool, err error) {
+	if r == nil {
+		return func() (int64, bool, error) {
+			err := f()
+			if permanent, ok := err.(*backoff.PermanentError); ok {
+				err = permanent.Err
+			}
+			return 0, false, err
+		}
+	}
+	return func() (int64, bool, error) {
+		rt := newRetrier(r, desync)
+		for {
+			err := f()
+			if err == nil {
+				return rt.retries, false, nil
+			}
+			next, ok := rt.next(err)
+			if !ok {
+				if permanent, ok := err.(*backoff.PermanentError); ok {
+					err = permanent.Err
+				}
+				if rt.exhausted() {
+					return rt.retries, true, fmt.Errorf("%w: %w", ErrRetriesExhausted, err)
+				}
+				return rt.retries, false, err
+			}
+			if notify != nil {
+				notify(err, next)
+			}
+			time.Sleep(next)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+	"github.com/cenkalti/backoff"
+)
+
+func TestRetryPresets(t *testing.T) {
+	presets := map[string]func() *errgroup.RetryOption{
+		"aggressive":      errgroup.RetryAggressive,
+		"conservative":    errgroup.RetryConservative,
+		"network default": errgroup.RetryNetworkDefault,
+	}
+
+	for name, preset := range presets {
+		if preset() == preset() {
+			t.Errorf("%s preset returned a shared RetryOption", name)
+		}
+		if preset().MaxRetries <= 0 {
+			t.Errorf("%s preset does not retry", name)
+		}
+	}
+
+	var calls int64
+	retryMode := errgroup.RetryAggressive()
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, retryMode, 1)
+	g.Go(func() error {
+		atomic.AddInt64(&calls, 1)
+		return errors.New("retry_test: always fail")
+	})
+	if err := g.Wait(); len(err) != 1 {
+		t.Errorf("g.Wait() returned %d errors; want 1", len(err))
+	}
+	if want := retryMode.MaxRetries + 1; calls != want {
+		t.Errorf("func called %d times; want %d", calls, want)
+	}
+}
+
+func TestBackoffSelector(t *testing.T) {
+	errTimeout := errors.New("retry_test: timeout")
+	errBadInput := errors.New("retry_test: bad input")
+
+	cases := []struct {
+		err   error
+		calls int64
+	}{
+		{err: errTimeout, calls: 4},
+		{err: errBadInput, calls: 1},
+	}
+
+	for _, tc := range cases {
+		var calls int64
+		tc := tc
+		g, _ := errgroup.NewGroupWithContext(
+			context.Background(),
+			1,
+			true,
+			&errgroup.RetryOption{
+				MaxRetries: 3,
+				Selector: func(err error) backoff.BackOff {
+					if errors.Is(err, errTimeout) {
+						return backoff.NewConstantBackOff(time.Millisecond)
+					}
+					return nil
+				},
+			},
+			1)
+		g.Go(func() error {
+			atomic.AddInt64(&calls, 1)
+			return tc.err
+		})
+		if err := g.Wait(); len(err) != 1 || !errors.Is(<-err, tc.err) {
+			t.Errorf("g.Wait() did not report %v", tc.err)
+		}
+		if calls != tc.calls {
+			t.Errorf("func failing with %v called %d times; want %d", tc.err, calls, tc.calls)
+		}
+	}
+}
+
+func TestRetryDesync(t *testing.T) {
+	const (
+		tasks    = 8
+		interval = time.Millisecond * 20
+	)
+	var (
+		mu      sync.Mutex
+		retried []time.Time
+	)
+	g, _ := errgroup.NewGroupWithContext(
+		context.Background(),
+		tasks,
+		true,
+		&errgroup.RetryOption{
+			Mode:       errgroup.Constant,
+			Interval:   interval,
+			MaxRetries: 1,
+		},
+		tasks,
+		errgroup.WithRetryDesync())
+	start := time.Now()
+	for i := 0; i < tasks; i++ {
+		attempt := 0
+		g.Go(func() error {
+			attempt++
+			if attempt == 1 {
+				return errors.New("retry_test: first attempt")
+			}
+			mu.Lock()
+			retried = append(retried, time.Now())
+			mu.Unlock()
+			return nil
+		})
+	}
+	g.Wait()
+
+	earliest, latest := interval*2, time.Duration(0)
+	for _, at := range retried {
+		d := at.Sub(start)
+		if d < interval {
+			t.Errorf("retried after %v; want at least %v", d, interval)
+		}
+		if d < earliest {
+			earliest = d
+		}
+		if d > latest {
+			latest = d
+		}
+	}
+	if latest-earliest < time.Millisecond {
+		t.Errorf("retries spread over %v; want them desynchronized", latest-earliest)
+	}
+}
+
+func TestRetryOptionReuse(t *testing.T) {
+	retryMode := &errgroup.RetryOption{
+		Mode:       errgroup.Constant,
+		Interval:   time.Millisecond,
+		MaxRetries: 2,
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var calls int64
+			g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, retryMode, 2)
+			for j := 0; j < 2; j++ {
+				g.Go(func() error {
+					atomic.AddInt64(&calls, 1)
+					return errors.New("retry_test: always fail")
+				})
+			}
+			g.Wait()
+			if calls != 6 {
+				t.Errorf("funcs called %d times; want 6", calls)
+			}
+		}()
+	}
+	wg.Wait()
+
+	var calls int64
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, retryMode, 1)
+	retryMode.MaxRetries = 0
+	g.Go(func() error {
+		atomic.AddInt64(&calls, 1)
+		return errors.New("retry_test: always fail")
+	})
+	g.Wait()
+	if calls != 3 {
+		t.Errorf("func called %d times after option changed; want 3", calls)
+	}
+}
@@ -0,0 +1,60 @@
+package errgroup
+
+// ErrSampling define which errors to collect when a huge batch fails, see `WithErrSampling`
+type ErrSampling struct {
+	// keep every Nth error of a category, <= 1 mean keep all
+	Every int
+	// keep at most the first K sampled errors of a category, <= 0 mean no limit
+	PerCategory int
+	// category of an error, nil mean all errors are of category ""
+	Classify func(err error) string
+}
+
+// collect only sampled errors, so millions of funcs failing with distinct messages not blow up
+// memory, errors not sampled are counted by `ErrorTotals` but not returned by `Wait`
+func WithErrSampling(s ErrSampling) Option {
+	return func(g *Group) {
+		g.err.sampling = &s
+	}
+}
+
+// number of errors occurred per category of `WithErrSampling`, sampled out or dropped due to
+// `maxErrs` included, all in category "" without sampling
+func (g *Group) ErrorTotals() map[string]int {
+	g.err.mu.Lock()
+	defer g.err.mu.Unlock()
+	totals := make(map[string]int, len(g.err.totals))
+	for category, n := range g.err.totals {
+		totals[category] = n
+	}
+	return totals
+}
+
+// count `err` and report whether it's sampled, must hold `e.mu`
+func (e *errCh) sample(err error) bool {
+	if e.totals == nil {
+		e.totals = make(map[string]int)
+	}
+	var category string
+	if e.sampling != nil && e.sampling.Classify != nil {
+		category = e.sampling.Classify(err)
+	}
+	e.totals[category]++
+	if e.sampling == nil {
+		return true
+	}
+	n := e.totals[category]
+	if e.sampling.Every > 1 && (n-1)%e.sampling.Every != 0 {
+		return false
+	}
+	if e.sampling.PerCategory > 0 {
+		every := e.sampling.Every
+		if every < 1 {
+			every = 1
+		}
+		if (n-1)/every >= e.sampling.PerCategory {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestErrSampling(t *testing.T) {
+	errTimeout := errors.New("sampling_test: timeout")
+	classify := errgroup.ClassifyIs(errTimeout)
+
+	cases := []struct {
+		sampling errgroup.ErrSampling
+		want     map[string]int
+	}{
+		{sampling: errgroup.ErrSampling{}, want: map[string]int{errTimeout.Error(): 10, "": 10}},
+		{sampling: errgroup.ErrSampling{Every: 3}, want: map[string]int{"": 7}},
+		{sampling: errgroup.ErrSampling{PerCategory: 2, Classify: classify}, want: map[string]int{errTimeout.Error(): 2, "": 2}},
+		{sampling: errgroup.ErrSampling{Every: 4, PerCategory: 2, Classify: classify}, want: map[string]int{errTimeout.Error(): 2, "": 2}},
+	}
+
+	for _, tc := range cases {
+		g, _ := errgroup.NewGroupWithContext(context.Background(), 4, true, nil, 0, errgroup.WithErrSampling(tc.sampling))
+		for i := 0; i < 20; i++ {
+			i := i
+			g.Go(func() error {
+				if i%2 == 0 {
+					return fmt.Errorf("task %d: %w", i, errTimeout)
+				}
+				return fmt.Errorf("task %d: unknown", i)
+			})
+		}
+		errs := g.Wait()
+
+		got := g.ErrorCategories(classify)
+		n := 0
+		for category, want := range tc.want {
+			n += want
+			if tc.sampling.Classify != nil && got[category] != want {
+				t.Errorf("%+v sampled %d errors of category %q; want %d", tc.sampling, got[category], category, want)
+			}
+		}
+		if len(errs) != n {
+			t.Errorf("%+v sampled %d errors; want %d", tc.sampling, len(errs), n)
+		}
+
+		totals := g.ErrorTotals()
+		if tc.sampling.Classify == nil && totals[""] != 20 {
+			t.Errorf("%+v counted %d errors; want 20", tc.sampling, totals[""])
+		}
+		if tc.sampling.Classify != nil && (totals[""] != 10 || totals[errTimeout.Error()] != 10) {
+			t.Errorf("%+v counted %v errors; want 10 per category", tc.sampling, totals)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package errgroup
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LoadShedding protect a struggling downstream, see `WithLoadShedding`
+type LoadShedding struct {
+	// moving average of attempt latency above which funcs are not started
+	Latency time.Duration
+	// true mean funcs wait until latency recovers instead of failing with `ErrOverloaded`
+	Defer bool
+}
+
+// stop starting funcs while the moving average of attempt latency exceeds `s.Latency`, they fail
+// with `ErrOverloaded` or wait with `s.Defer`, instead of piling more work onto a slow downstream
+// a func is always started if none is running, so latency can recover
+func WithLoadShedding(s LoadShedding) Option {
+	return func(g *Group) {
+		if s.Latency > 0 {
+			g.shedder = &shedder{cfg: s, recovered: make(chan struct{})}
+		}
+	}
+}
+
+type shedder struct {
+	cfg     LoadShedding
+	mu      sync.Mutex
+	avg     float64
+	running int
+	// closed and replaced once latency recovers or no func is running
+	recovered chan struct{}
+}
+
+// wait or return error unless a func may start, call `done` once it returned
+func (s *shedder) admit(ctx context.Context) error {
+	for {
+		s.mu.Lock()
+		if s.avg <= float64(s.cfg.Latency) || s.running == 0 {
+			s.running++
+			s.mu.Unlock()
+			return nil
+		}
+		recovered := s.recovered
+		s.mu.Unlock()
+		if !s.cfg.Defer {
+			return ErrOverloaded
+		}
+		select {
+		case <-recovered:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *shedder) done() {
+	s.mu.Lock()
+	s.running--
+	s.signal()
+	s.mu.Unlock()
+}
+
+// update the moving average by an attempt taking `d`
+func (s *shedder) observe(d time.Duration) {
+	s.mu.Lock()
+	s.avg += pressureAlpha * (float64(d) - s.avg)
+	s.signal()
+	s.mu.Unlock()
+}
+
+// wake waiting funcs if they may start, must hold `s.mu`
+func (s *shedder) signal() {
+	if s.avg <= float64(s.cfg.Latency) || s.running == 0 {
+		close(s.recovered)
+		s.recovered = make(chan struct{})
+	}
+}
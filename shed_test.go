@@ -0,0 +1,50 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestLoadShedding(t *testing.T) {
+	for _, deferred := range []bool{false, true} {
+		g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0,
+			errgroup.WithLoadShedding(errgroup.LoadShedding{Latency: 5 * time.Millisecond, Defer: deferred}))
+		// the downstream gets slow
+		for i := 0; i < 8; i++ {
+			name := fmt.Sprintf("slow-%d", i)
+			g.GoNamed(name, func() error {
+				time.Sleep(10 * time.Millisecond)
+				return nil
+			})
+			<-g.DoneCh(name)
+		}
+
+		started, release := make(chan struct{}), make(chan struct{})
+		g.Go(func() error {
+			close(started)
+			<-release
+			return nil
+		})
+		<-started
+		called := false
+		g.GoNamed("shed", func() error {
+			called = true
+			return nil
+		})
+		if !deferred {
+			if err := <-g.DoneCh("shed"); !errors.Is(err, errgroup.ErrOverloaded) {
+				t.Errorf("func started while overloaded returned %v; want %v", err, errgroup.ErrOverloaded)
+			}
+		}
+		close(release)
+		g.Wait()
+		if called != deferred {
+			t.Errorf("with Defer %v func started while overloaded was called: %v; want %v", deferred, called, deferred)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package errgroup
+
+import (
+	"container/heap"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/cenkalti/backoff"
+)
+
+var errSimulated = errors.New("errgroup: simulated failure")
+
+// Workload describe funcs to simulate by `Simulate`
+type Workload struct {
+	// number of funcs passed to `Go` at the same time
+	Tasks int
+	// latency of one attempt, nil mean zero
+	Latency func(r *rand.Rand) time.Duration
+	// probability an attempt fails, `attempt` start from 1, nil mean never fail
+	FailureRate func(attempt int) float64
+	// seed of the random source passed to `Latency`, also drawing failures and jitter of retries
+	Seed int64
+}
+
+// Projection is the result of `Simulate`
+type Projection struct {
+	// time from submission until all funcs returned
+	Duration time.Duration
+	// attempts made by all funcs, retries included
+	Attempts int64
+	// retries made by all funcs
+	Retries int64
+	// funcs failed after all retries
+	Failed int64
+	// max number of funcs running at the same time, `Tasks` capped by `maxConcurrency` as
+	// all funcs are passed at once and hold their slot while retrying
+	PeakConcurrency int64
+}
+
+// project how a group created with `maxConcurrency` and `retryMode` would run workload `w`
+// without calling anything, to tune limits and retry policies before hammering real dependencies
+// failed attempts fail with an internal error, `RetryIf` and `Selector` are consulted with it,
+// jitter of `Exponential` mode is drawn from `Seed` so a seed always gives the same projection,
+// policies returned by `Selector` run on the real clock and random source
+// only a `waitAll` group without options is modelled, fail-fast cancellation, `Option`s (e.g.
+// `WithRetryDesync`, pools or rate limits) and funcs passed over time are not
+func Simulate(w Workload, maxConcurrency int64, retryMode *RetryOption) Projection {
+	var (
+		p     Projection
+		rnd   = rand.New(rand.NewSource(w.Seed))
+		slots = &timeHeap{}
+	)
+	if w.Tasks <= 0 {
+		return p
+	}
+	slotCount := int64(w.Tasks)
+	if maxConcurrency > 0 && maxConcurrency < slotCount {
+		slotCount = maxConcurrency
+	}
+	for i := int64(0); i < slotCount; i++ {
+		heap.Push(slots, time.Duration(0))
+	}
+	p.PeakConcurrency = slotCount
+
+	// a func hold its slot during retries, funcs take free slots in submission order
+	for i := 0; i < w.Tasks; i++ {
+		start := heap.Pop(slots).(time.Duration)
+		end := start
+		var rt *retrier
+		if retryMode != nil {
+			rt = newRetrier(retryMode, false)
+			if retryMode.Selector == nil && retryMode.Mode == Exponential {
+				rt.policy = &simExponential{rnd: rnd, interval: backoff.DefaultInitialInterval, start: start, now: &end}
+			}
+		}
+		for attempt := 1; ; attempt++ {
+			p.Attempts++
+			if w.Latency != nil {
+				end += w.Latency(rnd)
+			}
+			if w.FailureRate == nil || rnd.Float64() >= w.FailureRate(attempt) {
+				break
+			}
+			delay, ok := time.Duration(0), false
+			if rt != nil {
+				delay, ok = rt.next(errSimulated)
+			}
+			if !ok {
+				p.Failed++
+				break
+			}
+			p.Retries++
+			end += delay
+		}
+		if end > p.Duration {
+			p.Duration = end
+		}
+		heap.Push(slots, end)
+	}
+	return p
+}
+
+// `backoff.NewExponentialBackOff` drawing jitter from `rnd` and counting its max elapsed time
+// in simulated time, `now` is the simulated time of the func using it
+type simExponential struct {
+	rnd      *rand.Rand
+	interval time.Duration
+	start    time.Duration
+	now      *time.Duration
+}
+
+func (b *simExponential) NextBackOff() time.Duration {
+	if *b.now-b.start > backoff.DefaultMaxElapsedTime {
+		return backoff.Stop
+	}
+	delta := backoff.DefaultRandomizationFactor * float64(b.interval)
+	min, max := float64(b.interval)-delta, float64(b.interval)+delta
+	next := time.Duration(min + b.rnd.Float64()*(max-min+1))
+	if float64(b.interval) >= float64(backoff.DefaultMaxInterval)/backoff.DefaultMultiplier {
+		b.interval = backoff.DefaultMaxInterval
+	} else {
+		b.interval = time.Duration(float64(b.interval) * backoff.DefaultMultiplier)
+	}
+	return next
+}
+
+func (b *simExponential) Reset() {
+	b.interval = backoff.DefaultInitialInterval
+	b.start = *b.now
+}
+
+// min heap of times slots become free
+type timeHeap []time.Duration
+
+func (h timeHeap) Len() int            { return len(h) }
+func (h timeHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h timeHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *timeHeap) Push(x interface{}) { *h = append(*h, x.(time.Duration)) }
+func (h *timeHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
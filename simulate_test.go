@@ -0,0 +1,79 @@
+package errgroup_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestSimulate(t *testing.T) {
+	fixed := func(*rand.Rand) time.Duration { return time.Millisecond * 10 }
+	// the first attempt of every func fails, the second succeeds
+	firstFails := func(attempt int) float64 {
+		if attempt == 1 {
+			return 1
+		}
+		return 0
+	}
+	retryMode := &errgroup.RetryOption{
+		Mode:       errgroup.Constant,
+		Interval:   time.Millisecond * 5,
+		MaxRetries: 1,
+	}
+
+	cases := []struct {
+		w              errgroup.Workload
+		maxConcurrency int64
+		retryMode      *errgroup.RetryOption
+		want           errgroup.Projection
+	}{
+		{
+			w:              errgroup.Workload{Tasks: 10, Latency: fixed},
+			maxConcurrency: 5,
+			want:           errgroup.Projection{Duration: time.Millisecond * 20, Attempts: 10, PeakConcurrency: 5},
+		},
+		{
+			w:              errgroup.Workload{Tasks: 4, Latency: fixed},
+			maxConcurrency: 0,
+			want:           errgroup.Projection{Duration: time.Millisecond * 10, Attempts: 4, PeakConcurrency: 4},
+		},
+		{
+			w:              errgroup.Workload{Tasks: 4, Latency: fixed, FailureRate: firstFails},
+			maxConcurrency: 2,
+			retryMode:      retryMode,
+			want:           errgroup.Projection{Duration: time.Millisecond * 50, Attempts: 8, Retries: 4, PeakConcurrency: 2},
+		},
+		{
+			w:              errgroup.Workload{Tasks: 4, Latency: fixed, FailureRate: firstFails},
+			maxConcurrency: 2,
+			want:           errgroup.Projection{Duration: time.Millisecond * 20, Attempts: 4, Failed: 4, PeakConcurrency: 2},
+		},
+	}
+
+	for _, tc := range cases {
+		if got := errgroup.Simulate(tc.w, tc.maxConcurrency, tc.retryMode); got != tc.want {
+			t.Errorf("Simulate(%+v, %d) = %+v; want %+v", tc.w, tc.maxConcurrency, got, tc.want)
+		}
+	}
+}
+
+func TestSimulateExponentialSeeded(t *testing.T) {
+	w := errgroup.Workload{
+		Tasks:       20,
+		Latency:     func(r *rand.Rand) time.Duration { return time.Duration(r.Intn(100)) * time.Millisecond },
+		FailureRate: func(int) float64 { return 0.5 },
+		Seed:        42,
+	}
+	retryMode := &errgroup.RetryOption{Mode: errgroup.Exponential, MaxRetries: 5}
+	first := errgroup.Simulate(w, 4, retryMode)
+	for i := 0; i < 5; i++ {
+		if got := errgroup.Simulate(w, 4, retryMode); got != first {
+			t.Fatalf("Simulate() = %+v, then %+v for the same seed; want the same projection", first, got)
+		}
+	}
+	if first.Retries == 0 {
+		t.Errorf("Simulate() made no retries; want some with failure rate 0.5")
+	}
+}
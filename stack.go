@@ -0,0 +1,24 @@
+package errgroup
+
+// StackError is an error of a func with the stack of the goroutine which called `Go` for it
+type StackError struct {
+	Err error
+	// stack captured when the func was passed to `Go`
+	Stack []byte
+}
+
+func (e *StackError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *StackError) Unwrap() error {
+	return e.Err
+}
+
+// wrap every error of funcs with `*StackError`, so it's possible to tell which `Go` call
+// produced which error, capture stack on every `Go` call has its cost, use it when debugging
+func WithErrorStacks() Option {
+	return func(g *Group) {
+		g.stacks = true
+	}
+}
@@ -0,0 +1,36 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func submitFailing(g *errgroup.Group, err error) {
+	g.Go(func() error { return err })
+}
+
+func TestErrorStacks(t *testing.T) {
+	errFail := errors.New("stack_test: failed")
+
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 1, errgroup.WithErrorStacks())
+	submitFailing(g, errFail)
+	errs := g.Wait()
+	if len(errs) != 1 {
+		t.Fatalf("g.Wait() returned %d errors; want 1", len(errs))
+	}
+	err := <-errs
+	var se *errgroup.StackError
+	if !errors.As(err, &se) {
+		t.Fatalf("g.Wait() = %#v; want a *StackError", err)
+	}
+	if !errors.Is(err, errFail) || err.Error() != errFail.Error() {
+		t.Errorf("StackError does not carry %v", errFail)
+	}
+	if !strings.Contains(string(se.Stack), "submitFailing") {
+		t.Errorf("StackError.Stack does not contain the caller of Go:\n%s", se.Stack)
+	}
+}
@@ -0,0 +1,44 @@
+package errgroup
+
+import "time"
+
+// Stats is a snapshot of a group's health, see `Stats`
+type Stats struct {
+	// number of funcs passed to the group
+	Submitted int64
+	// number of funcs waiting to start, e.g. for a slot, a delay or the next run of `GoEvery`
+	Queued int64
+	// number of funcs running now
+	Running int64
+	// number of funcs returned nil
+	Succeeded int64
+	// number of funcs failed, include those could not start
+	Failed int64
+	// number of retries made by all funcs
+	Retried int64
+	// number of funcs aborted by `Task.Cancel`
+	Cancelled int64
+	// number of running attempts stalled now, see `WithStallDetection`
+	Stalled int64
+	// time since the group was created
+	Elapsed time.Duration
+}
+
+// snapshot of the group, can be called at any time, so services can expose group health
+// without instrumenting every func
+func (g *Group) Stats() Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	s := g.summary
+	return Stats{
+		Submitted: s.Submitted,
+		Queued:    s.Submitted - g.finished - g.running,
+		Running:   g.running,
+		Succeeded: s.Succeeded,
+		Failed:    s.Failed,
+		Retried:   s.Retries,
+		Cancelled: s.Cancelled,
+		Stalled:   g.stalled,
+		Elapsed:   time.Since(g.start),
+	}
+}
@@ -0,0 +1,37 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestStats(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, &errgroup.RetryOption{Mode: errgroup.Constant, MaxRetries: 1}, 0)
+	started, release := make(chan struct{}), make(chan struct{})
+	g.Go(func() error {
+		close(started)
+		<-release
+		return nil
+	})
+	<-started
+	g.Go(func() error { return errors.New("stats_test: failed") })
+	cancelled := g.Go(func() error { return nil })
+	s := g.Stats()
+	if s.Submitted != 3 || s.Running != 1 || s.Queued != 2 {
+		t.Errorf("g.Stats() = %+v; want 3 submitted, 1 running, 2 queued", s)
+	}
+	cancelled.Cancel()
+	close(release)
+	g.Wait()
+	s = g.Stats()
+	want := errgroup.Stats{Submitted: 3, Succeeded: 1, Failed: 1, Retried: 1, Cancelled: 1, Elapsed: s.Elapsed}
+	if s != want {
+		t.Errorf("g.Stats() = %+v; want %+v", s, want)
+	}
+	if s.Elapsed <= 0 {
+		t.Errorf("g.Stats().Elapsed = %v; want > 0", s.Elapsed)
+	}
+}
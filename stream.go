@@ -0,0 +1,84 @@
+package errgroup
+
+import "sync"
+
+// forward values to a channel as they occur without blocking funcs
+type stream[T any] struct {
+	ch     chan T
+	mu     sync.Mutex
+	cond   *sync.Cond
+	queue  []T
+	closed bool
+}
+
+func newStream[T any](values []T) *stream[T] {
+	s := &stream[T]{ch: make(chan T), queue: values}
+	s.cond = sync.NewCond(&s.mu)
+	go s.forward()
+	return s
+}
+
+func (s *stream[T]) forward() {
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			close(s.ch)
+			return
+		}
+		v := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+		s.ch <- v
+	}
+}
+
+func (s *stream[T]) push(v T) {
+	s.mu.Lock()
+	if !s.closed {
+		s.queue = append(s.queue, v)
+	}
+	s.mu.Unlock()
+	s.cond.Signal()
+}
+
+func (s *stream[T]) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.cond.Signal()
+}
+
+// channel delivering errors as they occur, before `Wait` returned, to show failures of a long
+// batch in real time, errors occurred before the first call are delivered first, every call
+// return the same channel, it's closed once the group completed, see `Wait`
+// errors are not consumed from `Wait` nor dropped due to `maxErrs`, but merged ones are delivered
+// once each occurrence, see `WithErrorDedup`, the channel must be drained to release resources
+func (g *Group) ErrStream() <-chan error {
+	g.err.mu.Lock()
+	defer g.err.mu.Unlock()
+	if g.err.stream == nil {
+		errs := make([]error, 0, len(g.err.errs))
+		for _, entry := range g.err.errs {
+			errs = append(errs, entry.err)
+		}
+		g.err.stream = newStream(errs)
+		if g.err.closed {
+			g.err.stream.close()
+		}
+	}
+	return g.err.stream.ch
+}
+
+// stop streaming errors once the group completed
+func (e *errCh) closeStream() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closed = true
+	if e.stream != nil {
+		e.stream.close()
+	}
+}
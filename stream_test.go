@@ -0,0 +1,45 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestErrStream(t *testing.T) {
+	err1 := errors.New("stream_test: 1")
+	err2 := errors.New("stream_test: 2")
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 1)
+
+	g.Go(func() error { return err1 })
+	for g.Err() == nil {
+		time.Sleep(time.Millisecond)
+	}
+	stream := g.ErrStream()
+	if got := <-stream; got != err1 {
+		t.Errorf("error occurred before g.ErrStream() = %v; want %v", got, err1)
+	}
+
+	release := make(chan struct{})
+	g.Go(func() error { return err2 })
+	g.Go(func() error {
+		<-release
+		return nil
+	})
+	// delivered before the group completed, though dropped due to `maxErrs`
+	if got := <-stream; got != err2 {
+		t.Errorf("<-g.ErrStream() = %v; want %v", got, err2)
+	}
+	close(release)
+
+	g.Wait()
+	if _, ok := <-stream; ok {
+		t.Errorf("g.ErrStream() was not closed after g.Wait()")
+	}
+	if g.ErrStream() != stream {
+		t.Errorf("g.ErrStream() returned another channel")
+	}
+}
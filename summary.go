@@ -0,0 +1,48 @@
+package errgroup
+
+// Summary describe how funcs of a group ended
+type Summary struct {
+	// number of funcs passed to `Go`
+	Submitted int64
+	// number of funcs returned nil
+	Succeeded int64
+	// number of funcs failed, include those could not start
+	Failed int64
+	// number of retries made by all funcs
+	Retries int64
+	// number of funcs succeeded after at least one retry
+	RetrySucceeded int64
+	// number of funcs failed after all retries were used
+	RetriesExhausted int64
+	// number of optional funcs failed or skipped, see `Degradations`
+	Degraded int64
+	// number of funcs aborted by `Task.Cancel`
+	Cancelled int64
+}
+
+// increase one of `g.summary` counters
+func (g *Group) count(n *int64) {
+	g.mu.Lock()
+	*n++
+	g.mu.Unlock()
+}
+
+// update retry counters once a func returned
+func (g *Group) countRetries(retries int64, exhausted bool, err error) {
+	g.mu.Lock()
+	g.summary.Retries += retries
+	if retries > 0 && err == nil {
+		g.summary.RetrySucceeded++
+	}
+	if exhausted {
+		g.summary.RetriesExhausted++
+	}
+	g.mu.Unlock()
+}
+
+// counters of the group so far, can be called at any time
+func (g *Group) Summary() Summary {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.summary
+}
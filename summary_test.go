@@ -0,0 +1,48 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestSummaryRetries(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(
+		context.Background(),
+		3,
+		true,
+		&errgroup.RetryOption{
+			Mode:       errgroup.Constant,
+			Interval:   time.Millisecond,
+			MaxRetries: 2,
+		},
+		3)
+
+	// succeed at once, succeed after one retry, never succeed
+	for _, fails := range []int{0, 1, 3} {
+		fails := fails
+		g.Go(func() error {
+			if fails > 0 {
+				fails--
+				return errors.New("summary_test: failed")
+			}
+			return nil
+		})
+	}
+	g.Wait()
+
+	want := errgroup.Summary{
+		Submitted:        3,
+		Succeeded:        2,
+		Failed:           1,
+		Retries:          3,
+		RetrySucceeded:   1,
+		RetriesExhausted: 1,
+	}
+	if got := g.Summary(); got != want {
+		t.Errorf("g.Summary() = %+v; want %+v", got, want)
+	}
+}
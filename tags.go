@@ -0,0 +1,47 @@
+package errgroup
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// running unit func with the group's ctx tagged with `tags`, e.g. customer or shard, passed to
+// hooks in `TaskInfo`, to event handlers and loggers in `Event` and added to its error, see
+// `TaskError`, so failures and metrics can be broken down by them, `tags` is copied
+func (g *Group) GoTagged(tags map[string]string, f func(ctx context.Context) error) *Task {
+	t := g.newTask("", f)
+	t.tags = copyTags(tags)
+	return g.submit(t)
+}
+
+// tags of the func running with `ctx`, nil if untagged, false if `ctx` is not passed by the
+// group, see `GoTagged`
+func TaskTags(ctx context.Context) (map[string]string, bool) {
+	t, ok := ctx.Value(taskKey{}).(*task)
+	if !ok {
+		return nil, false
+	}
+	return copyTags(t.tags), true
+}
+
+func copyTags(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	c := make(map[string]string, len(tags))
+	for k, v := range tags {
+		c[k] = v
+	}
+	return c
+}
+
+// tags as `k=v` sorted by key
+func formatTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,86 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+// record tags passed to hooks
+type tagHook struct {
+	tags []map[string]string
+}
+
+func (h *tagHook) StartTask(ctx context.Context, info errgroup.TaskInfo) (context.Context, func(int, error)) {
+	h.tags = append(h.tags, info.Tags)
+	return ctx, func(int, error) {}
+}
+
+func (h *tagHook) StartAttempt(ctx context.Context, attempt int) (context.Context, func(error)) {
+	return ctx, func(error) {}
+}
+
+func TestGoTagged(t *testing.T) {
+	hook := &tagHook{}
+	var mu sync.Mutex
+	var events []errgroup.Event
+	var logged []interface{}
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0,
+		errgroup.WithHook(hook),
+		errgroup.WithEventHandler(func(e errgroup.Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, e)
+		}),
+		errgroup.WithLoggerAdapter(errgroup.LoggerFunc(func(level slog.Level, msg string, kv ...interface{}) {
+			mu.Lock()
+			defer mu.Unlock()
+			if msg == "errgroup: task failed" {
+				logged = kv
+			}
+		})))
+	tags := map[string]string{"shard": "3", "customer": "acme"}
+	errShard := errors.New("tags_test: shard down")
+	g.GoTagged(tags, func(ctx context.Context) error {
+		got, ok := errgroup.TaskTags(ctx)
+		if !ok || got["customer"] != "acme" || got["shard"] != "3" {
+			t.Errorf("TaskTags() = %v, %v; want tags of the func", got, ok)
+		}
+		return errShard
+	})
+	tags["shard"] = "4"
+	err := <-g.Wait()
+
+	var te *errgroup.TaskError
+	if !errors.As(err, &te) || te.Tags["shard"] != "3" || !errors.Is(err, errShard) {
+		t.Fatalf("g.Wait() = %v; want TaskError tagged shard 3", err)
+	}
+	if err.Error() != "task [customer=acme, shard=3]: tags_test: shard down" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if len(hook.tags) != 1 || hook.tags[0]["customer"] != "acme" {
+		t.Errorf("hook tags = %v; want customer acme", hook.tags)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, e := range events {
+		if e.Kind != errgroup.GroupCancelled && e.Tags["shard"] != "3" {
+			t.Errorf("%v event tags = %v; want shard 3", e.Kind, e.Tags)
+		}
+	}
+	if fmt.Sprint(logged[len(logged)-2:]) != "[tags map[customer:acme shard:3]]" {
+		t.Errorf("logged %v; want tags last", logged)
+	}
+}
+
+func TestTaskErrorNamedAndTagged(t *testing.T) {
+	err := &errgroup.TaskError{Name: "fetch", Tags: map[string]string{"shard": "1"}, Err: errors.New("down")}
+	if err.Error() != `task "fetch" [shard=1]: down` {
+		t.Errorf("Error() = %q", err.Error())
+	}
+}
@@ -0,0 +1,50 @@
+package errgroup
+
+import (
+	"context"
+	"errors"
+)
+
+// Task is a handle of a func passed to the group
+type Task struct {
+	t *task
+}
+
+// abort the func without cancelling its siblings, e.g. the user navigated away, a func not
+// started yet is never called, a running one see its ctx cancelled (funcs taking ctx only,
+// e.g. `GoContext`) and is not retried, the func is counted as `Summary.Cancelled` instead of
+// failed, so its error is neither reported nor cancel the group, no-op once it returned
+func (h *Task) Cancel() {
+	h.t.cancel(ErrTaskCancelled)
+}
+
+// closed once the func returned or was skipped, so a single func can be awaited while the
+// group keeps running, see `Task.Err`
+func (h *Task) Done() <-chan struct{} {
+	return h.t.finished
+}
+
+// error the func finally returned or was skipped with, wrapping `ErrTaskCancelled` if it was
+// aborted by `Task.Cancel`, nil on success or before `Task.Done` is closed
+func (h *Task) Err() error {
+	select {
+	case <-h.t.finished:
+		return h.t.err
+	default:
+		return nil
+	}
+}
+
+// true mean `t` was aborted by `Task.Cancel`
+func (t *task) cancelled() bool {
+	return errors.Is(context.Cause(t.ctx), ErrTaskCancelled)
+}
+
+// count `t` as cancelled if it was aborted by `Task.Cancel`
+func (g *Group) dropCancelled(t *task) bool {
+	if !t.cancelled() {
+		return false
+	}
+	g.count(&g.summary.Cancelled)
+	return true
+}
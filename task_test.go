@@ -0,0 +1,103 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestTaskCancel(t *testing.T) {
+	g, ctx := errgroup.NewGroupWithContext(context.Background(), 1, false, nil, 0)
+	started := make(chan struct{})
+	running := g.GoContext(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return context.Cause(ctx)
+	})
+	<-started
+	var called bool
+	waiting := g.Go(func() error {
+		called = true
+		return nil
+	})
+	waiting.Cancel()
+	running.Cancel()
+	errs := g.Wait()
+	if len(errs) != 0 {
+		t.Errorf("g.Wait() = %v; want no error", <-errs)
+	}
+	if called {
+		t.Errorf("cancelled func was called")
+	}
+	if s := g.Summary(); s.Cancelled != 2 || s.Failed != 0 {
+		t.Errorf("g.Summary() = %+v; want 2 cancelled, 0 failed", s)
+	}
+	if err := context.Cause(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("context.Cause(ctx) = %v; want %v", err, context.Canceled)
+	}
+}
+
+func TestTaskCancelSiblings(t *testing.T) {
+	g, ctx := errgroup.NewGroupWithContext(context.Background(), 0, false, nil, 0)
+	started := make(chan struct{})
+	task := g.GoContext(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	<-started
+	task.Cancel()
+	task.Cancel()
+	g.Go(func() error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return nil
+	})
+	g.Wait()
+	if s := g.Summary(); s.Succeeded != 1 || s.Cancelled != 1 {
+		t.Errorf("g.Summary() = %+v; want 1 succeeded, 1 cancelled", s)
+	}
+}
+
+func TestTaskDone(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	errFailed := errors.New("task_test: failed")
+	release := make(chan struct{})
+	slow := g.Go(func() error {
+		<-release
+		return nil
+	})
+	failed := g.Go(func() error { return errFailed })
+	<-failed.Done()
+	if err := failed.Err(); !errors.Is(err, errFailed) {
+		t.Errorf("failed.Err() = %v; want %v", err, errFailed)
+	}
+	select {
+	case <-slow.Done():
+		t.Errorf("slow.Done() closed before the func returned")
+	default:
+	}
+	if err := slow.Err(); err != nil {
+		t.Errorf("slow.Err() = %v before the func returned; want nil", err)
+	}
+
+	cancelled := g.GoContext(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	cancelled.Cancel()
+	<-cancelled.Done()
+	if err := cancelled.Err(); !errors.Is(err, errgroup.ErrTaskCancelled) {
+		t.Errorf("cancelled.Err() = %v; want %v", err, errgroup.ErrTaskCancelled)
+	}
+
+	close(release)
+	g.Wait()
+	<-slow.Done()
+	if err := slow.Err(); err != nil {
+		t.Errorf("slow.Err() = %v; want nil", err)
+	}
+}
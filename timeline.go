@@ -0,0 +1,233 @@
+package errgroup
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TimelineTask is a func recorded in the timeline, see `WithTimeline`
+type TimelineTask struct {
+	// empty for unnamed funcs
+	Name string
+	// when the func was passed, see `TaskInfo.Queued`
+	Queued time.Time
+	// when it got its slots and when it returned
+	Start, End time.Time
+	// attempts in the order they were made, more than one if retried
+	Attempts []TimelineAttempt
+	// final error, nil on success
+	Err error
+}
+
+// TimelineAttempt is one attempt of a func, see `TimelineTask`
+type TimelineAttempt struct {
+	Start, End time.Time
+	Err        error
+}
+
+// funcs recorded by `WithTimeline` in the order they started
+type timeline struct {
+	mu    sync.Mutex
+	tasks []*TimelineTask
+}
+
+// timeline hook, see `WithTimeline`
+type timelineHook struct {
+	tl *timeline
+}
+
+type timelineKey struct{}
+
+// record when every func waited, ran and retried, see `Timeline`, `WriteTimeline` and
+// `WriteChromeTrace`, to visualize a large batch and find where its wall-clock time went
+func WithTimeline() Option {
+	return func(g *Group) {
+		g.timeline = &timeline{}
+		WithHook(timelineHook{tl: g.timeline})(g)
+	}
+}
+
+func (h timelineHook) StartTask(ctx context.Context, info TaskInfo) (context.Context, func(int, error)) {
+	now := time.Now()
+	entry := &TimelineTask{Name: info.Name, Queued: info.Queued, Start: now}
+	h.tl.mu.Lock()
+	h.tl.tasks = append(h.tl.tasks, entry)
+	h.tl.mu.Unlock()
+	return context.WithValue(ctx, timelineKey{}, entry), func(_ int, err error) {
+		h.tl.mu.Lock()
+		defer h.tl.mu.Unlock()
+		entry.End, entry.Err = time.Now(), err
+	}
+}
+
+func (h timelineHook) StartAttempt(ctx context.Context, _ int) (context.Context, func(error)) {
+	entry, ok := ctx.Value(timelineKey{}).(*TimelineTask)
+	if !ok {
+		return ctx, func(error) {}
+	}
+	h.tl.mu.Lock()
+	entry.Attempts = append(entry.Attempts, TimelineAttempt{Start: time.Now()})
+	i := len(entry.Attempts) - 1
+	h.tl.mu.Unlock()
+	return ctx, func(err error) {
+		h.tl.mu.Lock()
+		defer h.tl.mu.Unlock()
+		entry.Attempts[i].End, entry.Attempts[i].Err = time.Now(), err
+	}
+}
+
+// funcs recorded so far in the order they started, ones still running have zero `End`, nil
+// unless `WithTimeline`
+func (g *Group) Timeline() []TimelineTask {
+	if g.timeline == nil {
+		return nil
+	}
+	g.timeline.mu.Lock()
+	defer g.timeline.mu.Unlock()
+	tasks := make([]TimelineTask, len(g.timeline.tasks))
+	for i, entry := range g.timeline.tasks {
+		tasks[i] = *entry
+		tasks[i].Attempts = append([]TimelineAttempt(nil), entry.Attempts...)
+	}
+	return tasks
+}
+
+// JSON form of `TimelineTask`, errors as strings
+type timelineTaskJSON struct {
+	Name     string                `json:"name,omitempty"`
+	Queued   time.Time             `json:"queued"`
+	Start    time.Time             `json:"start"`
+	End      time.Time             `json:"end"`
+	Attempts []timelineAttemptJSON `json:"attempts"`
+	Err      string                `json:"error,omitempty"`
+}
+
+type timelineAttemptJSON struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Err   string    `json:"error,omitempty"`
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// write `Timeline` to `w` as a JSON array, errors as strings
+func (g *Group) WriteTimeline(w io.Writer) error {
+	tasks := g.Timeline()
+	out := make([]timelineTaskJSON, len(tasks))
+	for i, task := range tasks {
+		out[i] = timelineTaskJSON{Name: task.Name, Queued: task.Queued, Start: task.Start, End: task.End, Err: errString(task.Err)}
+		out[i].Attempts = make([]timelineAttemptJSON, len(task.Attempts))
+		for j, attempt := range task.Attempts {
+			out[i].Attempts[j] = timelineAttemptJSON{Start: attempt.Start, End: attempt.End, Err: errString(attempt.Err)}
+		}
+	}
+	return json.NewEncoder(w).Encode(out)
+}
+
+// event of the Chrome trace-event format
+type traceEvent struct {
+	Name  string                 `json:"name"`
+	Cat   string                 `json:"cat,omitempty"`
+	Phase string                 `json:"ph"`
+	TS    int64                  `json:"ts"`
+	Dur   int64                  `json:"dur,omitempty"`
+	PID   int                    `json:"pid"`
+	TID   int                    `json:"tid"`
+	Args  map[string]interface{} `json:"args,omitempty"`
+}
+
+// processes of the trace, one for funcs waiting to start and one for running ones
+const (
+	traceRunning = 1
+	traceWaiting = 2
+)
+
+// write `Timeline` to `w` in the Chrome trace-event format, to load in chrome://tracing or
+// Perfetto, running funcs and their attempts are laid out on as few rows as possible, time
+// from being passed until started on rows of their own, funcs still running are left out
+func (g *Group) WriteChromeTrace(w io.Writer) error {
+	var tasks []TimelineTask
+	for _, task := range g.Timeline() {
+		if !task.End.IsZero() {
+			tasks = append(tasks, task)
+		}
+	}
+	var origin time.Time
+	for _, task := range tasks {
+		if origin.IsZero() || task.Queued.Before(origin) {
+			origin = task.Queued
+		}
+	}
+	micros := func(t time.Time) int64 {
+		return t.Sub(origin).Microseconds()
+	}
+	events := []traceEvent{
+		{Name: "process_name", Phase: "M", PID: traceRunning, Args: map[string]interface{}{"name": "running"}},
+		{Name: "process_name", Phase: "M", PID: traceWaiting, Args: map[string]interface{}{"name": "waiting"}},
+	}
+	running, waiting := &rows{}, &rows{}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Queued.Before(tasks[j].Queued)
+	})
+	for _, task := range tasks {
+		name := task.Name
+		if name == "" {
+			name = "func"
+		}
+		if task.Start.After(task.Queued) {
+			events = append(events, traceEvent{
+				Name: name, Cat: "wait", Phase: "X", PID: traceWaiting, TID: waiting.take(task.Queued, task.Start),
+				TS: micros(task.Queued), Dur: task.Start.Sub(task.Queued).Microseconds(),
+			})
+		}
+		args := map[string]interface{}{"attempts": len(task.Attempts)}
+		if task.Err != nil {
+			args["error"] = task.Err.Error()
+		}
+		tid := running.take(task.Start, task.End)
+		events = append(events, traceEvent{
+			Name: name, Cat: "task", Phase: "X", PID: traceRunning, TID: tid,
+			TS: micros(task.Start), Dur: task.End.Sub(task.Start).Microseconds(), Args: args,
+		})
+		for i, attempt := range task.Attempts {
+			args := map[string]interface{}{"attempt": i + 1}
+			if attempt.Err != nil {
+				args["error"] = attempt.Err.Error()
+			}
+			events = append(events, traceEvent{
+				Name: "attempt", Cat: "attempt", Phase: "X", PID: traceRunning, TID: tid,
+				TS: micros(attempt.Start), Dur: attempt.End.Sub(attempt.Start).Microseconds(), Args: args,
+			})
+		}
+	}
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
+
+// rows of a trace, spans of one row never overlap
+type rows struct {
+	ends []time.Time
+}
+
+// row for a span from `start` to `end`, the first free one
+func (r *rows) take(start, end time.Time) int {
+	for i, last := range r.ends {
+		if !last.After(start) {
+			r.ends[i] = end
+			return i + 1
+		}
+	}
+	r.ends = append(r.ends, end)
+	return len(r.ends)
+}
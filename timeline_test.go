@@ -0,0 +1,112 @@
+package errgroup_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestTimeline(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	g.Go(func() error { return nil })
+	g.Wait()
+	if tl := g.Timeline(); tl != nil {
+		t.Errorf("g.Timeline() = %v without WithTimeline; want nil", tl)
+	}
+
+	g, _ = errgroup.NewGroupWithContext(context.Background(), 1, true, &errgroup.RetryOption{
+		Mode: errgroup.Constant, Interval: time.Millisecond, MaxRetries: 1,
+	}, 0, errgroup.WithTimeline())
+	failed := errors.New("failed")
+	started := make(chan struct{})
+	g.GoNamed("slow", func() error {
+		close(started)
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	<-started
+	g.GoNamed("flaky", func() error { return failed })
+	g.Wait()
+	tl := g.Timeline()
+	if len(tl) != 2 || tl[0].Name != "slow" || tl[1].Name != "flaky" {
+		t.Fatalf("g.Timeline() = %+v; want slow then flaky", tl)
+	}
+	slow, flaky := tl[0], tl[1]
+	if len(slow.Attempts) != 1 || slow.Err != nil || slow.End.Sub(slow.Start) < 20*time.Millisecond {
+		t.Errorf("slow = %+v; want one successful attempt of 20ms", slow)
+	}
+	if flaky.Start.Sub(flaky.Queued) < 15*time.Millisecond {
+		t.Errorf("flaky waited %v; want about 20ms", flaky.Start.Sub(flaky.Queued))
+	}
+	if len(flaky.Attempts) != 2 || !errors.Is(flaky.Err, failed) || !errors.Is(flaky.Attempts[1].Err, failed) {
+		t.Errorf("flaky = %+v; want two failed attempts", flaky)
+	}
+
+	var buf bytes.Buffer
+	if err := g.WriteTimeline(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var tasks []struct {
+		Name     string
+		Attempts []struct{ Error string }
+		Error    string
+	}
+	if err := json.Unmarshal(buf.Bytes(), &tasks); err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 || tasks[1].Error == "" || len(tasks[1].Attempts) != 2 || tasks[1].Attempts[0].Error != "failed" {
+		t.Errorf("WriteTimeline() = %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := g.WriteChromeTrace(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var trace struct {
+		TraceEvents []struct {
+			Name string
+			Cat  string
+			Ph   string
+			TS   int64
+			Dur  int64
+			PID  int
+			TID  int
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatal(err)
+	}
+	cats := map[string]int{}
+	for _, e := range trace.TraceEvents {
+		cats[e.Cat+e.Name]++
+		if e.Cat == "task" && (e.PID != 1 || e.TID != 1) {
+			t.Errorf("task %s on %d/%d; want one row as funcs not overlap", e.Name, e.PID, e.TID)
+		}
+		if e.Cat == "wait" && e.Name == "flaky" && (e.PID != 2 || e.Dur < 15000) {
+			t.Errorf("wait = %+v; want flaky waiting about 20ms", e)
+		}
+	}
+	if cats["taskslow"] != 1 || cats["taskflaky"] != 1 || cats["attemptattempt"] != 3 || cats["waitflaky"] != 1 || cats["process_name"] != 2 {
+		t.Errorf("events = %v; want 2 tasks, 3 attempts, flaky waiting and 2 process names", cats)
+	}
+}
+
+func TestTimelineQueued(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0,
+		errgroup.WithQueue(10), errgroup.WithTimeline())
+	before := time.Now()
+	g.GoAfter(20*time.Millisecond, func(context.Context) error { return nil })
+	g.Wait()
+	tl := g.Timeline()
+	if len(tl) != 1 {
+		t.Fatalf("g.Timeline() recorded %d funcs; want 1", len(tl))
+	}
+	if task := tl[0]; task.Queued.Before(before) || task.Start.Sub(task.Queued) < 20*time.Millisecond {
+		t.Errorf("func started %v after it was queued; want the delay of GoAfter included", task.Start.Sub(task.Queued))
+	}
+}
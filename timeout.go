@@ -0,0 +1,41 @@
+package errgroup
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeoutError is reported when an attempt of a func ran out of its own deadline, ctx of the
+// group is not affected, see `GoWithTimeout`
+type TimeoutError struct {
+	// deadline of the attempt
+	Timeout time.Duration
+	// error returned by the func, usually `context.DeadlineExceeded`
+	Err error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("timeout after %v: %v", e.Timeout, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// give every attempt of funcs a ctx with deadline `d`, so a hung call not hold its slot forever,
+// funcs must watch ctx to return in time (e.g. `GoContext`), it's overridden by `GoWithTimeout`
+// and archetypes with a timeout, see `TimeoutError`
+func WithTaskTimeout(d time.Duration) Option {
+	return func(g *Group) {
+		g.taskTimeout = d
+	}
+}
+
+// running unit func whose every attempt get a ctx with deadline `d` derived from the group's
+// one, an attempt failed after the deadline is reported as `*TimeoutError`
+func (g *Group) GoWithTimeout(d time.Duration, f func(ctx context.Context) error) *Task {
+	t := g.newTask("", f)
+	t.timeout = d
+	return g.submit(t)
+}
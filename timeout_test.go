@@ -0,0 +1,69 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestGoWithTimeout(t *testing.T) {
+	g, ctx := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	hung := g.GoWithTimeout(10*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	fast := g.GoWithTimeout(time.Second, func(ctx context.Context) error { return nil })
+	<-hung.Done()
+	var te *errgroup.TimeoutError
+	if err := hung.Err(); !errors.As(err, &te) || te.Timeout != 10*time.Millisecond || !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("hung.Err() = %v; want *TimeoutError of 10ms", err)
+	}
+	if ctx.Err() != nil {
+		t.Errorf("ctx of the group cancelled by the timeout of a func")
+	}
+	g.Wait()
+	if err := fast.Err(); err != nil {
+		t.Errorf("fast.Err() = %v; want nil", err)
+	}
+}
+
+func TestGoWithTimeoutGroupCancelled(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, false, nil, 0)
+	task := g.GoWithTimeout(time.Minute, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	g.Go(func() error { return errors.New("timeout_test: failed") })
+	g.Wait()
+	var te *errgroup.TimeoutError
+	if err := task.Err(); errors.As(err, &te) {
+		t.Errorf("task.Err() = %v; want no *TimeoutError as the group was cancelled", err)
+	}
+}
+
+func TestWithTaskTimeout(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 1, true, nil, 0, errgroup.WithTaskTimeout(10*time.Millisecond))
+	hung := g.GoContext(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	longer := g.GoWithTimeout(time.Minute, func(ctx context.Context) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(30 * time.Millisecond):
+			return nil
+		}
+	})
+	g.Wait()
+	var te *errgroup.TimeoutError
+	if err := hung.Err(); !errors.As(err, &te) || te.Timeout != 10*time.Millisecond {
+		t.Errorf("hung.Err() = %v; want *TimeoutError of 10ms", err)
+	}
+	if err := longer.Err(); err != nil {
+		t.Errorf("longer.Err() = %v; want nil", err)
+	}
+}
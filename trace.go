@@ -0,0 +1,41 @@
+package errgroup
+
+import (
+	"context"
+	"runtime/trace"
+	"strconv"
+)
+
+// runtime/trace hook, see `WithRuntimeTrace`
+type traceHook struct{}
+
+// wrap every func in a runtime/trace task named after it and every attempt in a region, so
+// `go tool trace` shows funcs of the group as units of work, cheap when tracing is off
+func WithRuntimeTrace() Option {
+	return WithHook(traceHook{})
+}
+
+func (traceHook) StartTask(ctx context.Context, info TaskInfo) (context.Context, func(int, error)) {
+	name := info.Name
+	if name == "" {
+		name = "errgroup.task"
+	}
+	ctx, task := trace.NewTask(ctx, name)
+	if info.Wait > 0 {
+		trace.Log(ctx, "slot wait", info.Wait.String())
+	}
+	return ctx, func(attempts int, err error) {
+		trace.Log(ctx, "attempts", strconv.Itoa(attempts))
+		if err != nil {
+			trace.Log(ctx, "error", err.Error())
+		}
+		task.End()
+	}
+}
+
+func (traceHook) StartAttempt(ctx context.Context, attempt int) (context.Context, func(error)) {
+	region := trace.StartRegion(ctx, "attempt "+strconv.Itoa(attempt))
+	return ctx, func(error) {
+		region.End()
+	}
+}
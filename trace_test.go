@@ -0,0 +1,30 @@
+package errgroup_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime/trace"
+	"strings"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestWithRuntimeTrace(t *testing.T) {
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("trace.Start() = %v", err)
+	}
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0, errgroup.WithRuntimeTrace())
+	g.GoNamed("traced-func", func() error { return nil })
+	g.Go(func() error { return errors.New("trace_test: failed") })
+	g.Wait()
+	trace.Stop()
+	if !strings.Contains(buf.String(), "traced-func") {
+		t.Errorf("trace has no task named after the func")
+	}
+	if !strings.Contains(g.String(), "hooks") {
+		t.Errorf("g.String() = %q; want hooks", g.String())
+	}
+}
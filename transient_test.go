@@ -0,0 +1,85 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "transient_test: i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
+
+var _ net.Error = timeoutErr{}
+
+func TestIsTransient(t *testing.T) {
+	unavailable := errgroup.CheckResponse(&http.Response{StatusCode: http.StatusServiceUnavailable})
+	notFound := errgroup.CheckResponse(&http.Response{StatusCode: http.StatusNotFound})
+
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{err: nil, want: false},
+		{err: errors.New("transient_test: bad input"), want: false},
+		{err: context.Canceled, want: false},
+		{err: context.DeadlineExceeded, want: true},
+		{err: fmt.Errorf("read: %w", syscall.ECONNRESET), want: true},
+		{err: &net.OpError{Op: "dial", Err: timeoutErr{}}, want: true},
+		{err: fmt.Errorf("fetch: %w", unavailable), want: true},
+		{err: notFound, want: false},
+	}
+
+	for _, tc := range cases {
+		if got := errgroup.IsTransient(tc.err); got != tc.want {
+			t.Errorf("IsTransient(%v) = %v; want %v", tc.err, got, tc.want)
+		}
+	}
+	if err := errgroup.CheckResponse(&http.Response{StatusCode: http.StatusOK}); err != nil {
+		t.Errorf("CheckResponse(200) = %v; want nil", err)
+	}
+}
+
+func TestRetryIf(t *testing.T) {
+	cases := []struct {
+		err   error
+		calls int64
+	}{
+		{err: context.DeadlineExceeded, calls: 3},
+		{err: errors.New("transient_test: bad input"), calls: 1},
+	}
+
+	for _, tc := range cases {
+		var calls int64
+		tc := tc
+		g, _ := errgroup.NewGroupWithContext(
+			context.Background(),
+			1,
+			true,
+			&errgroup.RetryOption{
+				Mode:       errgroup.Constant,
+				Interval:   time.Millisecond,
+				MaxRetries: 2,
+				RetryIf:    errgroup.IsTransient,
+			},
+			1)
+		g.Go(func() error {
+			atomic.AddInt64(&calls, 1)
+			return tc.err
+		})
+		g.Wait()
+		if calls != tc.calls {
+			t.Errorf("func failing with %v called %d times; want %d", tc.err, calls, tc.calls)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package errgroup
+
+import (
+	"context"
+	"sync"
+)
+
+// Phases is a func passed by `GoTwoPhase` split into its prepare and commit steps
+type Phases struct {
+	// validate and stage the work without side effects visible to others
+	Prepare func(ctx context.Context) error
+	// apply the staged work, nil mean nothing to commit
+	Commit func(ctx context.Context) error
+}
+
+// run prepares of `steps` in parallel as funcs of the group and, only if all of them succeeded
+// and ctx of the group is not cancelled, their commits, a failed prepare cancel the other
+// prepares (see `Task.Cancel`) and no commit is run, so a batch is applied all or nothing
+// as long as commits not fail, see `Compensate` to roll them back
+// the returned task is done once all commits returned, it report the first failed prepare or
+// commit and cancelling it cancel prepares and commits not returned yet
+func (g *Group) GoTwoPhase(steps ...Phases) *Task {
+	whole := &task{finished: make(chan struct{})}
+	whole.ctx, whole.cancel = context.WithCancelCause(g.ctx)
+	var (
+		mu    sync.Mutex
+		tasks []*Task
+	)
+	// submit a step unless the whole was cancelled
+	submit := func(fn func(ctx context.Context) error) *Task {
+		mu.Lock()
+		defer mu.Unlock()
+		h := g.submit(g.newTask("", fn))
+		if whole.cancelled() {
+			h.Cancel()
+		}
+		tasks = append(tasks, h)
+		return h
+	}
+	stop := context.AfterFunc(whole.ctx, func() {
+		if !whole.cancelled() {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, h := range tasks {
+			h.Cancel()
+		}
+	})
+	prepares := make([]*Task, len(steps))
+	for i, s := range steps {
+		prepares[i] = submit(s.Prepare)
+	}
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer whole.cancel(nil)
+		defer stop()
+		err := awaitPrepares(prepares)
+		if err == nil && whole.ctx.Err() == nil {
+			var commits []*Task
+			for _, s := range steps {
+				if s.Commit != nil {
+					commits = append(commits, submit(s.Commit))
+				}
+			}
+			for _, c := range commits {
+				if <-c.Done(); err == nil {
+					err = c.Err()
+				}
+			}
+		}
+		if err == nil && whole.cancelled() {
+			err = ErrTaskCancelled
+		}
+		whole.err = err
+		close(whole.finished)
+	}()
+	return &Task{t: whole}
+}
+
+// wait for all `prepares` to return, cancel the others once one failed, return error of the
+// first failed one
+func awaitPrepares(prepares []*Task) error {
+	var (
+		once  sync.Once
+		first error
+		wg    sync.WaitGroup
+	)
+	wg.Add(len(prepares))
+	for _, p := range prepares {
+		go func(p *Task) {
+			defer wg.Done()
+			<-p.Done()
+			if err := p.Err(); err != nil {
+				once.Do(func() {
+					first = err
+					for _, p := range prepares {
+						p.Cancel()
+					}
+				})
+			}
+		}(p)
+	}
+	wg.Wait()
+	return first
+}
@@ -0,0 +1,97 @@
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestGoTwoPhase(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0)
+	var prepared, committed int32
+	step := errgroup.Phases{
+		Prepare: func(ctx context.Context) error {
+			atomic.AddInt32(&prepared, 1)
+			return nil
+		},
+		Commit: func(ctx context.Context) error {
+			if atomic.LoadInt32(&prepared) != 3 {
+				return errors.New("twophase_test: committed before all prepared")
+			}
+			atomic.AddInt32(&committed, 1)
+			return nil
+		},
+	}
+	g.GoTwoPhase(step, step, step)
+	if errs := g.Wait(); len(errs) > 0 {
+		t.Errorf("g.Wait() = %v; want no error", <-errs)
+	}
+	if committed != 3 {
+		t.Errorf("committed = %d; want 3", committed)
+	}
+}
+
+func TestGoTwoPhaseAborted(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	errInvalid := errors.New("twophase_test: invalid")
+	var committed int32
+	commit := func(ctx context.Context) error {
+		atomic.AddInt32(&committed, 1)
+		return nil
+	}
+	task := g.GoTwoPhase(
+		errgroup.Phases{
+			Prepare: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			Commit: commit,
+		},
+		errgroup.Phases{
+			Prepare: func(ctx context.Context) error { return errInvalid },
+			Commit:  commit,
+		},
+	)
+	errs := g.Wait()
+	if committed != 0 {
+		t.Errorf("committed = %d; want 0", committed)
+	}
+	if len(errs) != 1 || !errors.Is(<-errs, errInvalid) {
+		t.Errorf("g.Wait() want only %v", errInvalid)
+	}
+	if s := g.Summary(); s.Cancelled != 1 {
+		t.Errorf("g.Summary() = %+v; want the other prepare cancelled", s)
+	}
+	if !errors.Is(task.Err(), errInvalid) {
+		t.Errorf("task.Err() = %v; want %v", task.Err(), errInvalid)
+	}
+}
+
+func TestGoTwoPhaseCancel(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 0, true, nil, 0)
+	var committed int32
+	started := make(chan struct{})
+	task := g.GoTwoPhase(errgroup.Phases{
+		Prepare: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		Commit: func(ctx context.Context) error {
+			atomic.AddInt32(&committed, 1)
+			return nil
+		},
+	})
+	<-started
+	task.Cancel()
+	<-task.Done()
+	if !errors.Is(task.Err(), errgroup.ErrTaskCancelled) {
+		t.Errorf("task.Err() = %v; want ErrTaskCancelled", task.Err())
+	}
+	if errs := g.Wait(); len(errs) > 0 || committed != 0 {
+		t.Errorf("g.Wait() returned %d errors, committed %d; want none", len(errs), committed)
+	}
+}
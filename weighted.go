@@ -0,0 +1,19 @@
+package errgroup
+
+import "context"
+
+// running unit func taking `w` slots of `maxConcurrency`, so heavy funcs and light ones share
+// one budget, `w` < 1 mean 1 and `w` > `maxConcurrency` mean all slots
+func (g *Group) GoWeighted(w int64, f func() error) *Task {
+	return g.GoWeightedContext(w, withoutCtx(f))
+}
+
+// running unit func taking `w` slots like `GoWeighted` with the group's ctx carrying settings
+// of the func
+func (g *Group) GoWeightedContext(w int64, f func(ctx context.Context) error) *Task {
+	t := g.newTask("", f)
+	if w > 1 {
+		t.weight = w
+	}
+	return g.submit(t)
+}
@@ -0,0 +1,59 @@
+package errgroup_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestGoWeighted(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 4, true, nil, 0)
+	var (
+		used, peak int64
+	)
+	track := func(w int64) func() error {
+		return func() error {
+			n := atomic.AddInt64(&used, w)
+			for {
+				p := atomic.LoadInt64(&peak)
+				if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt64(&used, -w)
+			return nil
+		}
+	}
+	for i := 0; i < 10; i++ {
+		g.GoWeighted(3, track(3))
+		g.GoWeighted(1, track(1))
+	}
+	// heavier than the whole budget, takes all slots instead of waiting forever
+	g.GoWeighted(10, track(4))
+
+	if errs := g.Wait(); len(errs) > 0 {
+		t.Errorf("g.Wait() = %v; want no error", <-errs)
+	}
+	if peak > 4 {
+		t.Errorf("funcs took %d slots at a time; want at most 4", peak)
+	}
+}
+
+func TestGoWeightedContext(t *testing.T) {
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 4, true, nil, 0)
+	task := g.GoWeightedContext(2, func(ctx context.Context) error {
+		if _, ok := errgroup.IdempotencyKey(ctx); !ok {
+			t.Errorf("ctx of GoWeightedContext not passed by the group")
+		}
+		return nil
+	})
+	<-task.Done()
+	if task.Err() != nil {
+		t.Errorf("task.Err() = %v; want nil", task.Err())
+	}
+	g.Wait()
+}
@@ -0,0 +1,14 @@
+package errgroup
+
+import "context"
+
+// id of the worker of `WithWorkerPool` running the func with `ctx`, counting from 1, e.g. to log
+// which worker ran what when workers keep local state, false if `ctx` is not passed by the group
+// or the func is not run by a worker of the pool, see `Event.Worker` too
+func WorkerID(ctx context.Context) (int, bool) {
+	t, ok := ctx.Value(taskKey{}).(*task)
+	if !ok || t.worker == 0 {
+		return 0, false
+	}
+	return t.worker, true
+}
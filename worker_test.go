@@ -0,0 +1,61 @@
+package errgroup_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/FelixSeptem/errgroup"
+)
+
+func TestWorkerID(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		started = map[int]int{}
+	)
+	g, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0, errgroup.WithWorkerPool(),
+		errgroup.WithEventHandler(func(e errgroup.Event) {
+			if e.Kind == errgroup.TaskStarted {
+				mu.Lock()
+				started[e.Worker]++
+				mu.Unlock()
+			}
+		}))
+	ran := map[int]int{}
+	for i := 0; i < 20; i++ {
+		g.GoContext(func(ctx context.Context) error {
+			id, ok := errgroup.WorkerID(ctx)
+			if !ok {
+				t.Errorf("WorkerID() of func run by the pool returned false")
+			}
+			mu.Lock()
+			ran[id]++
+			mu.Unlock()
+			return nil
+		})
+	}
+	g.Wait()
+	for id := range ran {
+		if id < 1 || id > 2 {
+			t.Errorf("WorkerID() = %d; want 1 or 2", id)
+		}
+	}
+	if len(started) != len(ran) {
+		t.Errorf("TaskStarted events by worker = %v; want %v", started, ran)
+	}
+	for id, n := range ran {
+		if started[id] != n {
+			t.Errorf("TaskStarted events by worker = %v; want %v", started, ran)
+			break
+		}
+	}
+
+	plain, _ := errgroup.NewGroupWithContext(context.Background(), 2, true, nil, 0, errgroup.WithQueue(10))
+	plain.GoContext(func(ctx context.Context) error {
+		if id, ok := errgroup.WorkerID(ctx); ok {
+			t.Errorf("WorkerID() without a worker pool = %d; want false", id)
+		}
+		return nil
+	})
+	plain.Wait()
+}